@@ -1,17 +1,18 @@
 package xua
 
 import (
-	"bytes"
-	"encoding/binary"
+	"context"
 	"errors"
-	"io"
+	"sync"
 	"time"
 
-	"github.com/fkgi/xua/sctp"
+	"github.com/fkgi/xua/codec"
 )
 
 var (
-	eventStack = make(chan message, 1024)
+	eventStack chan message
+	eventLock  sync.RWMutex
+	serveLock  sync.Mutex
 	handler    func([]byte)
 
 	// tr    = time.Second * 2 // Pending Recovery timer
@@ -20,12 +21,24 @@ var (
 
 	requestStack   message = nil
 	RoutingContext []uint32
+
+	// ASPIdentifier is ASP Identifier sent in ASPUP. It is not sent if nil.
+	ASPIdentifier *uint32
+	// InfoString is Info String sent in ASPUP, ASPDN, ASPAC and ASPIA.
+	InfoString string
+	// HandleNotify is called when NTFY is received.
+	HandleNotify func(*NTFY)
+	// NetworkAppearance is Network Appearance for each Routing Context.
+	// It is sent with the Routing Context, and received message
+	// with different Network Appearance is answered by ERR.
+	NetworkAppearance map[uint32]uint32
 )
 
+// Traffic Mode Type values.
 const (
-	Override  uint32 = 1
-	Loadshare uint32 = 2
-	Broadcast uint32 = 3
+	Override  = codec.Override
+	Loadshare = codec.Loadshare
+	Broadcast = codec.Broadcast
 )
 
 /*
@@ -64,27 +77,6 @@ Message class = 0x06
 // 0x0e Congestion Indication
 // 0x0f Data Acknowledge
 
-CO: SCCP Connection-Oriented Messages
-Message class = 0x08
-// 0x01 Connection Request (CORE)
-// 0x02 Connection Acknowledge (COAK)
-// 0x03 Connection Refused (COREF)
-// 0x04 Release Request (RELRE)
-// 0x05 Release Complete (RELCO)
-// 0x06 Reset Confirm (RESCO)
-// 0x07 Reset Request (RESRE)
-// 0x08 Connection Oriented Data Transfer (CODT)
-// 0x09 Connection Oriented Data Acknowledge (CODA)
-// 0x0a Connection Oriented Error (COERR)
-// 0x0b Inactivity Test (COIT)
-
-RKM: Routing Key Management Messages
-Message class = 0x09
-// 0x01 Registration Request (REG REQ)
-// 0x02 Registration Response (REG RSP)
-// 0x03 Deregistration Request (DEREG REQ)
-// 0x04 Deregistration Response (DEREG RSP)
-
 IIM: Interface Identifier Management Messages
 Message class = 0x0a
 // 0x01 Registration Request (REG REQ)
@@ -93,18 +85,7 @@ Message class = 0x0a
 // 0x04 Deregistration Response (DEREG RSP)
 */
 
-/*
-Message of xUA
-
-	 0                   1                   2                   3
-	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|    Version    |   Reserved    | Message Class | Message Type  |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|                        Message Length                         |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|                         Message Data                          |
-*/
+// message is xUA message handled by the event handler.
 type message interface {
 	// handleMessage handles this message
 	handleMessage()
@@ -112,11 +93,7 @@ type message interface {
 	// handleResult handles result of this message
 	handleResult(message)
 
-	// marshal returns Message Class, Message Type and binary Message Data
-	marshal() (uint8, uint8, []byte)
-
-	// unmarshal decodes specified Tag/length TLV value from reader
-	unmarshal(uint16, uint16, io.ReadSeeker) error
+	codec.Message
 }
 
 func writeHandler(m message) (e error) {
@@ -124,191 +101,453 @@ func writeHandler(m message) (e error) {
 		e = errors.New("any other request is waiting answer")
 		return
 	}
-	cls, typ, b := m.marshal()
-	buf := new(bytes.Buffer)
-
-	// version
-	buf.WriteByte(1)
-	// reserved
-	buf.WriteByte(0)
-	// Message Class
-	buf.WriteByte(cls)
-	// Message Type
-	buf.WriteByte(typ)
-	// Message Length
-	binary.Write(buf, binary.BigEndian, uint32(len(b)+8))
-	// Message Data
-	buf.Write(b)
-
-	if e = sctp.Write(buf.Bytes(), 0); e != nil {
+	if e = writeMessage(m, 0); e != nil {
 		return
 	}
 
 	requestStack = m
 	time.AfterFunc(tack, func() {
-		if requestStack == m {
-			// Protocol Error
-			eventStack <- &ERR{code: 0x07}
-		}
+		// Protocol Error if m is still waiting answer
+		putEvent(&ERR{ERR: codec.ERR{Code: ErrProtocolError}, timeout: m})
 	})
 	return
 }
 
-func readHandler(buf []byte) {
+// writeMessage sends message on SCTP stream s.
+func writeMessage(m message, s uint16) error {
+	b, e := codec.Marshal(m)
+	if e != nil {
+		return e
+	}
+	return DefaultTransport.Write(b, s)
+}
+
+// dataStream returns SCTP stream for data message with sequence control.
+// Stream 0 is used only for management messages if other streams are available.
+func dataStream(seq uint32) uint16 {
+	out, _ := DefaultTransport.Streams()
+	if out <= 1 {
+		return 0
+	}
+	return uint16(seq%uint32(out-1)) + 1
+}
+
+func readHandler(buf []byte, s uint16) {
 	// rx message handler
-	if buf[0] != 1 || len(buf) < 8 {
-		// invalid version
+	m, e := decodeMessage(buf)
+	if e != nil {
+		if de, ok := e.(*DecodeError); ok {
+			errorResponse(buf, de.Code())
+		}
 		return
 	}
 
-	r := bytes.NewReader(buf[4:])
-	var l uint32
-	if e := binary.Read(r, binary.BigEndian, &l); e != nil {
+	switch buf[2] {
+	case 0x00, 0x03, 0x09:
+		// MGMT, ASPSM and RKM message must be received on stream 0
+		if s != 0 {
+			errorResponse(buf, ErrInvalidStreamIdentifier)
+			return
+		}
+	}
+	if !validNetworkAppearance(m) {
+		errorResponse(buf, ErrInvalidNetworkAppearance)
 		return
 	}
+	putEvent(m)
+}
 
-	var m message = nil
-	switch buf[2] {
-	case 0x00:
-		switch buf[3] {
-		case 0x00:
-			m = new(ERR)
-		case 0x01:
-			m = new(NTFY)
+// networkAppearance returns configured Network Appearance for ctx.
+func networkAppearance(ctx []uint32) *uint32 {
+	for _, c := range ctx {
+		if na, ok := NetworkAppearance[c]; ok {
+			return &na
 		}
-	case 0x02:
-		switch buf[3] {
-		case 0x01:
-			m = new(DUNA)
-		case 0x02:
-			m = new(DAVA)
-		case 0x04:
-			m = new(SCON)
-		case 0x05:
-			m = new(DUPU)
-		case 0x06:
-			m = new(DRST)
+	}
+	return nil
+}
+
+// splitRoutingContext splits ctx to the groups of same Network Appearance.
+func splitRoutingContext(ctx []uint32) (ret [][]uint32) {
+	idx := map[int64]int{}
+	for _, c := range ctx {
+		k := int64(-1)
+		if na, ok := NetworkAppearance[c]; ok {
+			k = int64(na)
 		}
-	case 0x03:
-		switch buf[3] {
-		case 0x03:
-			m = &BEAT{tx: false}
-		case 0x04:
-			m = new(ASPUPAck)
-		case 0x05:
-			m = new(ASPDNAck)
-		case 0x06:
-			m = &BEATAck{tx: false}
+		if i, ok := idx[k]; ok {
+			ret[i] = append(ret[i], c)
+		} else {
+			idx[k] = len(ret)
+			ret = append(ret, []uint32{c})
 		}
-	case 0x04:
-		switch buf[3] {
-		case 0x03:
-			m = new(ASPACAck)
-		case 0x04:
-			m = new(ASPIAAck)
+	}
+	if len(ret) == 0 {
+		ret = append(ret, ctx)
+	}
+	return
+}
+
+// dataRoutingContext returns Routing Context and Network Appearance of
+// sending data. The first group of splitRoutingContext is used,
+// so that all Routing Context in a message has same Network Appearance.
+func dataRoutingContext() ([]uint32, *uint32) {
+	rc := splitRoutingContext(RoutingContext)[0]
+	return rc, networkAppearance(rc)
+}
+
+// validNetworkAppearance checks Network Appearance of received message.
+// It must match with configured one for the Routing Context,
+// or with any configured one if the message has no Routing Context.
+func validNetworkAppearance(m message) bool {
+	var ctx []uint32
+	var na *uint32
+	switch m := m.(type) {
+	case *CLDT:
+		ctx, na = m.RoutingContext, m.NetworkAppearance
+	case *CLDR:
+		ctx, na = m.RoutingContext, m.NetworkAppearance
+	case *DUNA:
+		ctx, na = m.RoutingContext, m.NetworkAppearance
+	case *DAVA:
+		ctx, na = m.RoutingContext, m.NetworkAppearance
+	case *SCON:
+		ctx, na = m.RoutingContext, m.NetworkAppearance
+	case *DUPU:
+		ctx, na = m.RoutingContext, m.NetworkAppearance
+	case *DRST:
+		ctx, na = m.RoutingContext, m.NetworkAppearance
+	}
+	if na == nil || len(NetworkAppearance) == 0 {
+		return true
+	}
+	if len(ctx) == 0 {
+		for _, v := range NetworkAppearance {
+			if v == *na {
+				return true
+			}
 		}
-	case 0x07:
-		switch buf[3] {
-		case 0x01:
-			m = &CLDT{tx: false}
-		case 0x02:
-			m = &CLDR{tx: false}
+		return false
+	}
+	for _, c := range ctx {
+		if v, ok := NetworkAppearance[c]; ok && v != *na {
+			return false
 		}
 	}
+	return true
+}
 
+// newMessage returns xUA message for the decoded message,
+// or Error Code if the message is not acceptable.
+func newMessage(m codec.Message) (message, ErrorCode) {
+	switch m := m.(type) {
+	case *codec.ERR:
+		return &ERR{ERR: *m}, 0
+	case *codec.NTFY:
+		return &NTFY{NTFY: *m}, 0
+	case *codec.DUNA:
+		return &DUNA{DUNA: *m}, 0
+	case *codec.DAVA:
+		return &DAVA{DAVA: *m}, 0
+	case *codec.SCON:
+		return &SCON{SCON: *m}, 0
+	case *codec.DUPU:
+		return &DUPU{DUPU: *m}, 0
+	case *codec.DRST:
+		return &DRST{DRST: *m}, 0
+	case *codec.BEAT:
+		return &BEAT{BEAT: *m}, 0
+	case *codec.ASPUPAck:
+		return &ASPUPAck{ASPUPAck: *m}, 0
+	case *codec.ASPDNAck:
+		return &ASPDNAck{ASPDNAck: *m}, 0
+	case *codec.BEATAck:
+		return &BEATAck{BEATAck: *m}, 0
+	case *codec.ASPACAck:
+		return &ASPACAck{ASPACAck: *m}, 0
+	case *codec.ASPIAAck:
+		return &ASPIAAck{ASPIAAck: *m}, 0
+	case *codec.CLDT:
+		return &CLDT{CLDT: *m}, 0
+	case *codec.CLDR:
+		return &CLDR{CLDR: *m}, 0
+	case *codec.DAUD, *codec.ASPUP, *codec.ASPDN, *codec.ASPAC, *codec.ASPIA:
+		// messages for SGP
+		return nil, ErrUnexpectedMessage
+	}
+	// CO and RKM are not supported
+	return nil, ErrUnsupportedMessageClass
+}
+
+// decodeMessage decodes received message.
+// Returned error is *DecodeError.
+func decodeMessage(buf []byte) (message, error) {
+	cm, e := codec.Unmarshal(buf)
+	if e != nil {
+		return nil, e
+	}
+	m, c := newMessage(cm)
 	if m == nil {
+		return nil, &DecodeError{
+			Class: buf[2], Type: buf[3], Offset: 2, Err: c}
+	}
+	return m, nil
+}
+
+// errorResponse sends ERR with the received message as diagnostic info.
+// Received ERR is not answered to avoid loop.
+func errorResponse(buf []byte, code ErrorCode) {
+	if len(buf) >= 4 && buf[2] == 0x00 && buf[3] == 0x00 {
 		return
 	}
+	info := make([]byte, len(buf))
+	copy(info, buf)
+	putEvent(&ERR{
+		ERR: codec.ERR{Code: code, DiagnosticInfo: info},
+		tx:  true})
+}
 
-	r = bytes.NewReader(buf[8:l])
-	for r.Len() > 4 {
-		var t, l uint16
-		if e := binary.Read(r, binary.BigEndian, &t); e != nil {
-			break
-		}
-		if e := binary.Read(r, binary.BigEndian, &l); e != nil {
-			break
-		}
-		l -= 4
+// putEvent queues m to the event handler.
+// It returns false if ASP is not running.
+func putEvent(m message) bool {
+	return putEventContext(context.Background(), m) == nil
+}
 
-		if e := m.unmarshal(t, l, r); e != nil {
-			break
-		}
+// putEventContext queues m to the event handler until ctx is done.
+func putEventContext(ctx context.Context, m message) error {
+	eventLock.RLock()
+	defer eventLock.RUnlock()
+	if eventStack == nil {
+		return errors.New("ASP is not running")
+	}
+	select {
+	case eventStack <- m:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-		if l%4 != 0 {
-			r.Seek(int64(4-l%4), io.SeekCurrent)
-		}
+// request queues m and waits result r of the request until ctx is done.
+func request(ctx context.Context, m message, r chan error) error {
+	if e := putEventContext(ctx, m); e != nil {
+		return e
+	}
+	select {
+	case e := <-r:
+		return e
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// closeEvent stops the event handler of es.
+func closeEvent(es chan message) {
+	eventLock.Lock()
+	defer eventLock.Unlock()
+	if eventStack == es {
+		close(es)
+		eventStack = nil
 	}
-	eventStack <- m
 }
 
-// Serve connects and active ASP
+func eventHandler(es chan message) {
+	for e, ok := <-es; ok; e, ok = <-es {
+		e.handleMessage()
+	}
+	if requestStack != nil {
+		// association is closed before answer
+		requestStack.handleResult(nil)
+		requestStack = nil
+	}
+}
+
+// Serve connects and active ASP on DefaultTransport.
+// It returns when the association is closed, and can be called again after that.
+// handleData receives data if no handler is registered by RegisterSubsystem.
 func Serve(handleData func([]byte), handleUp, handleDown func()) error {
+	return ServeContext(context.Background(), handleData, handleUp, handleDown)
+}
+
+// ServeContext is Serve with ctx.
+// When ctx is done, pending ASPUP and ASPAC are cancelled and
+// the association is shutdown, then it returns ctx.Err().
+func ServeContext(ctx context.Context, handleData func([]byte), handleUp, handleDown func()) (e error) {
+	if !serveLock.TryLock() {
+		return errors.New("ASP is already running")
+	}
+	defer serveLock.Unlock()
+
+	eventLock.Lock()
+	es := make(chan message, 1024)
+	eventStack = es
+	eventLock.Unlock()
+
 	handler = handleData
-	return sctp.Serve(
-		readHandler,
-		func() {
-			go func() {
-				// event handler
-				for e, ok := <-eventStack; ok; e, ok = <-eventStack {
-					e.handleMessage()
-				}
-			}()
+	fin := make(chan struct{})
+	go func() {
+		eventHandler(es)
+		close(fin)
+	}()
+	defer func() {
+		closeEvent(es)
+		<-fin
+	}()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c, cancel := context.WithTimeout(context.Background(), tack)
+			defer cancel()
+			if Shutdown(c) != nil &&
+				DefaultTransport.Abort("shutdown failed") != nil {
+				DefaultTransport.Close()
+			}
+		case <-stop:
+		}
+	}()
 
+	e = DefaultTransport.Serve(
+		func(b []byte, s uint16, _ uint32) {
+			readHandler(b, s)
+		},
+		func() {
 			r := make(chan error, 1)
-			eventStack <- &ASPUP{result: r}
-			if e := <-r; e != nil {
-				sctp.Abort("invalid ASP message")
+			if e := request(ctx, &ASPUP{
+				ASPUP: codec.ASPUP{
+					ASPIdentifier: ASPIdentifier,
+					InfoString:    InfoString},
+				result: r}, r); e != nil {
+				if ctx.Err() == nil {
+					DefaultTransport.Abort("invalid ASP message")
+				}
 				return
 			}
-			eventStack <- &ASPAC{
-				mode:   Loadshare,
-				ctx:    RoutingContext,
-				result: r}
-			if e := <-r; e != nil {
-				sctp.Abort("invalid ASP message")
+			if e := Activate(ctx); e != nil {
+				if ctx.Err() == nil {
+					DefaultTransport.Abort("invalid ASP message")
+				}
+				return
 			}
 
 			go handleUp()
 		},
 		func() {
-			close(eventStack)
+			closeEvent(es)
 			go handleDown()
 		})
+	if ctx.Err() != nil {
+		e = ctx.Err()
+	}
+	return
+}
+
+// Activate sends ASPAC for all RoutingContext and waits the answer until ctx is done.
+// ASPAC is sent for each Network Appearance of RoutingContext.
+func Activate(ctx context.Context) error {
+	for _, rc := range splitRoutingContext(RoutingContext) {
+		r := make(chan error, 1)
+		if e := request(ctx, &ASPAC{
+			ASPAC: codec.ASPAC{
+				TrafficMode:       Loadshare,
+				RoutingContext:    rc,
+				NetworkAppearance: networkAppearance(rc),
+				InfoString:        InfoString},
+			result: r}, r); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// Inactivate sends ASPIA for all RoutingContext and waits the answer until ctx is done.
+// ASPIA is sent for each Network Appearance of RoutingContext as Activate.
+func Inactivate(ctx context.Context) error {
+	for _, rc := range splitRoutingContext(RoutingContext) {
+		r := make(chan error, 1)
+		if e := request(ctx, &ASPIA{
+			ASPIA: codec.ASPIA{
+				RoutingContext: rc,
+				InfoString:     InfoString},
+			result: r}, r); e != nil {
+			return e
+		}
+	}
+	return nil
 }
 
 // Close disconnect ASP
 func Close() error {
+	return CloseContext(context.Background())
+}
+
+// CloseContext is Close with ctx.
+// The association is aborted if ctx is done before ASPDN is answered.
+func CloseContext(ctx context.Context) error {
 	r := make(chan error, 1)
-	eventStack <- &ASPDN{result: r}
-	<-r
+	e := request(ctx, &ASPDN{
+		ASPDN:  codec.ASPDN{InfoString: InfoString},
+		result: r}, r)
+	if ctx.Err() != nil {
+		DefaultTransport.Abort("close timeout")
+		return ctx.Err()
+	}
+	if ce := DefaultTransport.Close(); e == nil {
+		e = ce
+	}
+	return e
+}
 
-	return sctp.Close()
+// Shutdown deactivates and disconnects ASP gracefully.
+// ASPIA is sent for all RoutingContext, and ASPDN is sent after
+// all queued data is acknowledged, then the association is shutdown.
+// The association is aborted if ctx is done before completion.
+func Shutdown(ctx context.Context) error {
+	e := Inactivate(ctx)
+	if e == nil {
+		e = DefaultTransport.Flush(ctx)
+	}
+	if e == nil {
+		r := make(chan error, 1)
+		e = request(ctx, &ASPDN{
+			ASPDN:  codec.ASPDN{InfoString: InfoString},
+			result: r}, r)
+	}
+	if ctx.Err() != nil {
+		DefaultTransport.Abort("shutdown timeout")
+		return ctx.Err()
+	}
+	if e != nil {
+		DefaultTransport.Abort("shutdown failed")
+		return e
+	}
+	return DefaultTransport.Close()
 }
 
-func Write(cgpa, cdpa SCCPAddress, b []byte) {
-	eventStack <- &CLDT{
-		tx:  true,
-		ctx: RoutingContext,
-		// returnOnError: true,
-		cgpa: cgpa,
-		cdpa: cdpa,
-		data: b}
+// Write queues data as CLDT.
+// It returns error if cgpa or cdpa is invalid or ASP is not running.
+func Write(cgpa, cdpa SCCPAddress, b []byte) error {
+	return WriteContext(context.Background(), cgpa, cdpa, b)
 }
 
-/*
-	eventStack <- &CLDR{
-		tx:    true,
-		ctx:   RoutingContext,
-		cause: 0x0101,
-		cgpa: SCCPAddress{
-			NatureOfAddress: NAI_International,
-			NumberingPlan:   NPI_E164,
-			GlobalTitle:     "67890",
-			SubsystemNumber: 0x07},
-		cdpa: SCCPAddress{
-			NatureOfAddress: NAI_International,
-			NumberingPlan:   NPI_E164,
-			GlobalTitle:     "12345",
-			SubsystemNumber: 0x06}}
-*/
+// WriteContext queues data as CLDT until ctx is done.
+func WriteContext(ctx context.Context, cgpa, cdpa SCCPAddress, b []byte) error {
+	if e := cgpa.Validate(); e != nil {
+		return e
+	}
+	if e := cdpa.Validate(); e != nil {
+		return e
+	}
+	rc, na := dataRoutingContext()
+	return putEventContext(ctx, &CLDT{
+		CLDT: codec.CLDT{
+			RoutingContext:    rc,
+			NetworkAppearance: na,
+			// ReturnOnError: true,
+			SourceAddress:      cgpa,
+			DestinationAddress: cdpa,
+			Data:               b},
+		tx: true})
+}
@@ -0,0 +1,559 @@
+package xua
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fkgi/xua/codec"
+)
+
+// fakeSG is SG on the peer side of Pipe.
+// It answers ASPSM and ASPTM requests and passes all messages to rx.
+type fakeSG struct {
+	Transport
+	rx chan codec.Message
+
+	// reject, rejectAC and rejectDN are Error Code answered to
+	// ASPUP, ASPAC and ASPDN if not zero.
+	reject   ErrorCode
+	rejectAC ErrorCode
+	rejectDN ErrorCode
+	// dropIA is true if ASPIA is not answered.
+	dropIA bool
+}
+
+// newFakeSG connects DefaultTransport to fakeSG by Pipe.
+func newFakeSG(t *testing.T) *fakeSG {
+	a, b := Pipe()
+	old := DefaultTransport
+	t.Cleanup(func() { DefaultTransport = old })
+	DefaultTransport = a
+	return startFakeSG(t, b)
+}
+
+// startFakeSG serves fakeSG on b.
+func startFakeSG(t *testing.T, b Transport) *fakeSG {
+	sg := &fakeSG{Transport: b, rx: make(chan codec.Message, 64)}
+	go b.Serve(func(buf []byte, s uint16, _ uint32) {
+		m, e := codec.Unmarshal(buf)
+		if e != nil {
+			t.Errorf("SG: invalid message % x: %v", buf, e)
+			return
+		}
+		switch m := m.(type) {
+		case *codec.ASPUP:
+			if sg.reject != 0 {
+				sg.write(&codec.ERR{Code: sg.reject})
+			} else {
+				sg.write(&codec.ASPUPAck{})
+			}
+		case *codec.ASPDN:
+			if sg.rejectDN != 0 {
+				sg.write(&codec.ERR{Code: sg.rejectDN})
+			} else {
+				sg.write(&codec.ASPDNAck{})
+			}
+		case *codec.ASPAC:
+			if sg.rejectAC != 0 {
+				sg.write(&codec.ERR{Code: sg.rejectAC})
+			} else {
+				sg.write(&codec.ASPACAck{
+					TrafficMode:    m.TrafficMode,
+					RoutingContext: m.RoutingContext})
+			}
+		case *codec.ASPIA:
+			if !sg.dropIA {
+				sg.write(&codec.ASPIAAck{RoutingContext: m.RoutingContext})
+			}
+		}
+		sg.rx <- m
+	}, func() {}, func() {})
+	return sg
+}
+
+func (sg *fakeSG) write(m codec.Message) {
+	s := uint16(0)
+	if _, ok := m.(*codec.CLDT); ok {
+		s = 1
+	}
+	b, _ := codec.Marshal(m)
+	sg.Write(b, s)
+}
+
+// recv returns next message received by SG.
+func (sg *fakeSG) recv(t *testing.T) codec.Message {
+	t.Helper()
+	select {
+	case m := <-sg.rx:
+		return m
+	case <-time.After(time.Second * 3):
+		t.Fatal("SG: no message")
+	}
+	return nil
+}
+
+// noRecv checks that SG receives no message.
+func (sg *fakeSG) noRecv(t *testing.T) {
+	t.Helper()
+	select {
+	case m := <-sg.rx:
+		t.Fatalf("SG: unexpected message %#v", m)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+// serveASP starts ASP and waits until it is activated.
+func serveASP(t *testing.T, handleData func([]byte)) chan error {
+	t.Helper()
+	up := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(handleData, func() { close(up) }, func() {})
+	}()
+	select {
+	case <-up:
+	case <-time.After(time.Second * 3):
+		t.Fatal("ASP is not activated")
+	}
+	return done
+}
+
+// closeASP closes ASP and waits until Serve returns.
+func closeASP(t *testing.T, done chan error) {
+	t.Helper()
+	if e := Close(); e != nil {
+		t.Errorf("close: %v", e)
+	}
+	select {
+	case e := <-done:
+		if e != nil {
+			t.Errorf("serve: %v", e)
+		}
+	case <-time.After(time.Second * 3):
+		t.Fatal("Serve does not return")
+	}
+}
+
+func TestPipeASP(t *testing.T) {
+	RoutingContext = []uint32{101}
+	defer func() { RoutingContext = nil }()
+
+	sg := newFakeSG(t)
+	rx := make(chan []byte, 1)
+	done := serveASP(t, func(b []byte) { rx <- b })
+
+	// ASP-DOWN -> ASP-INACTIVE -> ASP-ACTIVE
+	if _, ok := sg.recv(t).(*codec.ASPUP); !ok {
+		t.Fatal("ASPUP is not sent first")
+	}
+	if m, ok := sg.recv(t).(*codec.ASPAC); !ok {
+		t.Fatal("ASPAC is not sent after ASPUP Ack")
+	} else if m.TrafficMode != Loadshare ||
+		len(m.RoutingContext) != 1 || m.RoutingContext[0] != 101 {
+		t.Errorf("invalid ASPAC %+v", m)
+	}
+
+	// data transfer in ASP-ACTIVE
+	cgpa := SCCPAddress{PointCode: PointCode{Value: 1}, SubsystemNumber: 6}
+	cdpa := SCCPAddress{PointCode: PointCode{Value: 2}, SubsystemNumber: 7}
+	if e := Write(cgpa, cdpa, []byte("to SG")); e != nil {
+		t.Fatalf("write: %v", e)
+	}
+	if m, ok := sg.recv(t).(*codec.CLDT); !ok {
+		t.Fatal("CLDT is not sent")
+	} else if string(m.Data) != "to SG" ||
+		m.SourceAddress.SubsystemNumber != 6 ||
+		m.DestinationAddress.SubsystemNumber != 7 {
+		t.Errorf("invalid CLDT %+v", m)
+	}
+	sg.write(&codec.CLDT{
+		SourceAddress: cdpa, DestinationAddress: cgpa, Data: []byte("to ASP")})
+	select {
+	case b := <-rx:
+		if !bytes.Equal(b, []byte("to ASP")) {
+			t.Errorf("invalid data %q", b)
+		}
+	case <-time.After(time.Second * 3):
+		t.Fatal("CLDT is not received")
+	}
+
+	// ASP-ACTIVE -> ASP-DOWN
+	closeASP(t, done)
+	if _, ok := sg.recv(t).(*codec.ASPDN); !ok {
+		t.Fatal("ASPDN is not sent")
+	}
+}
+
+func TestPipeASPRejected(t *testing.T) {
+	sg := newFakeSG(t)
+	sg.reject = ErrRefusedManagementBlocking
+
+	up := make(chan struct{})
+	down := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(func([]byte) {},
+			func() { close(up) }, func() { close(down) })
+	}()
+	if _, ok := sg.recv(t).(*codec.ASPUP); !ok {
+		t.Fatal("ASPUP is not sent")
+	}
+
+	// ASP stays in ASP-DOWN and association is aborted
+	select {
+	case <-down:
+	case <-up:
+		t.Fatal("ASP is activated by ERR")
+	case <-time.After(time.Second * 3):
+		t.Fatal("association is not aborted")
+	}
+	sg.noRecv(t)
+	<-done
+}
+
+func TestPipeActivateRejected(t *testing.T) {
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	// ASP-ACTIVE -> ASP-INACTIVE
+	if e := Inactivate(context.Background()); e != nil {
+		t.Fatalf("inactivate: %v", e)
+	}
+	if _, ok := sg.recv(t).(*codec.ASPIA); !ok {
+		t.Fatal("ASPIA is not sent")
+	}
+
+	// ASPAC is answered by ERR
+	sg.rejectAC = ErrInvalidRoutingContext
+	e := Activate(context.Background())
+	if !errors.Is(e, ErrInvalidRoutingContext) {
+		t.Errorf("activate: %v", e)
+	}
+	var ee *Error
+	if !errors.As(e, &ee) {
+		t.Errorf("activate: %T is not *Error", e)
+	}
+	if _, ok := sg.recv(t).(*codec.ASPAC); !ok {
+		t.Fatal("ASPAC is not sent")
+	}
+	closeASP(t, done)
+}
+
+func TestPipeCloseRejected(t *testing.T) {
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	// association is closed even if ASPDN is answered by ERR
+	sg.rejectDN = ErrUnexpectedMessage
+	if e := Close(); !errors.Is(e, ErrUnexpectedMessage) {
+		t.Errorf("close: %v", e)
+	}
+	if _, ok := sg.recv(t).(*codec.ASPDN); !ok {
+		t.Fatal("ASPDN is not sent")
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 3):
+		t.Fatal("Serve does not return")
+	}
+}
+
+func TestPipeNetworkAppearance(t *testing.T) {
+	RoutingContext = []uint32{101, 102}
+	NetworkAppearance = map[uint32]uint32{101: 1, 102: 2}
+	defer func() { RoutingContext, NetworkAppearance = nil, nil }()
+
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+
+	// ASPAC is sent for each Network Appearance
+	for _, rc := range []uint32{101, 102} {
+		m, ok := sg.recv(t).(*codec.ASPAC)
+		for !ok {
+			m, ok = sg.recv(t).(*codec.ASPAC)
+		}
+		if len(m.RoutingContext) != 1 || m.RoutingContext[0] != rc ||
+			m.NetworkAppearance == nil ||
+			*m.NetworkAppearance != NetworkAppearance[rc] {
+			t.Errorf("invalid ASPAC %+v", m)
+		}
+	}
+
+	// CLDT has Routing Context of one Network Appearance
+	Write(SCCPAddress{SubsystemNumber: 6}, SCCPAddress{SubsystemNumber: 7}, []byte("data"))
+	if m, ok := sg.recv(t).(*codec.CLDT); !ok {
+		t.Fatal("CLDT is not sent")
+	} else if len(m.RoutingContext) != 1 || m.RoutingContext[0] != 101 ||
+		m.NetworkAppearance == nil || *m.NetworkAppearance != 1 {
+		t.Errorf("invalid CLDT %+v", m)
+	}
+	closeASP(t, done)
+}
+
+// flushMarker passes nil to rx of sg when Flush is called,
+// so that the order of Flush and sent messages can be checked.
+type flushMarker struct {
+	Transport
+	sg *fakeSG
+}
+
+func (f flushMarker) Flush(ctx context.Context) error {
+	f.sg.rx <- nil
+	return f.Transport.Flush(ctx)
+}
+
+func TestPipeShutdown(t *testing.T) {
+	RoutingContext = []uint32{101, 102}
+	NetworkAppearance = map[uint32]uint32{101: 1, 102: 2}
+	defer func() { RoutingContext, NetworkAppearance = nil, nil }()
+
+	sg := newFakeSG(t)
+	DefaultTransport = flushMarker{Transport: DefaultTransport, sg: sg}
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+	sg.recv(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	if e := Shutdown(ctx); e != nil {
+		t.Fatalf("shutdown: %v", e)
+	}
+
+	// ASPIA for each Network Appearance -> flush -> ASPDN -> close
+	for _, rc := range []uint32{101, 102} {
+		if m, ok := sg.recv(t).(*codec.ASPIA); !ok {
+			t.Fatal("ASPIA is not sent")
+		} else if len(m.RoutingContext) != 1 || m.RoutingContext[0] != rc {
+			t.Errorf("invalid ASPIA %+v", m)
+		}
+	}
+	if m := sg.recv(t); m != nil {
+		t.Fatalf("%#v is sent before flush", m)
+	}
+	if _, ok := sg.recv(t).(*codec.ASPDN); !ok {
+		t.Fatal("ASPDN is not sent after flush")
+	}
+	select {
+	case e := <-done:
+		if e != nil {
+			t.Errorf("serve: %v", e)
+		}
+	case <-time.After(time.Second * 3):
+		t.Fatal("association is not closed")
+	}
+}
+
+func TestPipeShutdownTimeout(t *testing.T) {
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	// association is aborted if ASPIA is not answered
+	sg.dropIA = true
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	if e := Shutdown(ctx); e != ctx.Err() || e == nil {
+		t.Errorf("shutdown: %v", e)
+	}
+	if _, ok := sg.recv(t).(*codec.ASPIA); !ok {
+		t.Fatal("ASPIA is not sent")
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 3):
+		t.Fatal("association is not aborted")
+	}
+	sg.noRecv(t)
+}
+
+func TestPipeServeContextCancel(t *testing.T) {
+	sg := newFakeSG(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	up := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeContext(ctx, func([]byte) {}, func() { close(up) }, func() {})
+	}()
+	select {
+	case <-up:
+	case <-time.After(time.Second * 3):
+		t.Fatal("ASP is not activated")
+	}
+	sg.recv(t)
+	sg.recv(t)
+
+	// cancel shutdowns ASP gracefully
+	cancel()
+	if _, ok := sg.recv(t).(*codec.ASPIA); !ok {
+		t.Fatal("ASPIA is not sent")
+	}
+	if _, ok := sg.recv(t).(*codec.ASPDN); !ok {
+		t.Fatal("ASPDN is not sent")
+	}
+	select {
+	case e := <-done:
+		if !errors.Is(e, context.Canceled) {
+			t.Errorf("serve: %v", e)
+		}
+	case <-time.After(time.Second * 3):
+		t.Fatal("ServeContext does not return")
+	}
+}
+
+// stallTransport blocks Write while it is locked.
+type stallTransport struct {
+	Transport
+	sync.Mutex
+}
+
+func (s *stallTransport) Write(b []byte, st uint16) error {
+	s.Lock()
+	s.Unlock()
+	return s.Transport.Write(b, st)
+}
+
+func TestPipeContextDeadline(t *testing.T) {
+	sg := newFakeSG(t)
+	st := &stallTransport{Transport: DefaultTransport}
+	DefaultTransport = st
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	// ASPAC is not sent while the transport is stalled
+	st.Lock()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	if e := Activate(ctx); !errors.Is(e, context.DeadlineExceeded) {
+		t.Errorf("activate: %v", e)
+	}
+
+	// data is queued until the event queue is full
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	var e error
+	for i := 0; e == nil && i < 4096; i++ {
+		e = WriteContext(ctx, SCCPAddress{SubsystemNumber: 6},
+			SCCPAddress{SubsystemNumber: 7}, []byte("data"))
+	}
+	if !errors.Is(e, context.DeadlineExceeded) {
+		t.Errorf("write: %v", e)
+	}
+
+	DefaultTransport.Abort("")
+	st.Unlock()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 3):
+		t.Fatal("Serve does not return")
+	}
+}
+
+func TestWriteInvalidAddress(t *testing.T) {
+	cdpa := SCCPAddress{SubsystemNumber: 7}
+	if e := Write(SCCPAddress{GlobalTitle: "12x"}, cdpa, nil); !errors.Is(e, codec.ErrInvalidGlobalTitle) {
+		t.Errorf("write: %v", e)
+	}
+}
+
+func TestPipeErrorResponse(t *testing.T) {
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	for _, tc := range []struct {
+		name   string
+		buf    []byte
+		stream uint16
+		code   ErrorCode
+	}{
+		{"invalid version", []byte{2, 0, 0, 1, 0, 0, 0, 8}, 0, ErrInvalidVersion},
+		{"unknown class", []byte{1, 0, 10, 1, 0, 0, 0, 8}, 0, ErrUnsupportedMessageClass},
+		{"unknown type", []byte{1, 0, 3, 9, 0, 0, 0, 8}, 0, ErrUnsupportedMessageType},
+		{"bad length", []byte{1, 0, 3, 4, 0, 0, 0, 12}, 0, ErrParameterFieldError},
+		{"bad parameter", []byte{1, 0, 3, 4, 0, 0, 0, 12, 0, 0x11, 0, 8}, 0, ErrParameterFieldError},
+		{"message for SGP", []byte{1, 0, 3, 1, 0, 0, 0, 8}, 0, ErrUnexpectedMessage},
+		{"invalid stream", []byte{1, 0, 3, 4, 0, 0, 0, 8}, 1, ErrInvalidStreamIdentifier},
+	} {
+		sg.Write(tc.buf, tc.stream)
+		if m, ok := sg.recv(t).(*codec.ERR); !ok {
+			t.Fatalf("%s: ERR is not sent", tc.name)
+		} else if m.Code != tc.code || !bytes.Equal(m.DiagnosticInfo, tc.buf) {
+			t.Errorf("%s: invalid ERR code=%v info=% x", tc.name, m.Code, m.DiagnosticInfo)
+		}
+	}
+
+	// ERR is not answered even if it is invalid
+	sg.write(&codec.ERR{Code: ErrProtocolError})
+	sg.Write([]byte{1, 0, 0, 0, 0, 0, 0, 12}, 0)
+	sg.noRecv(t)
+
+	closeASP(t, done)
+	sg.recv(t)
+}
+
+func TestPipeInfoString(t *testing.T) {
+	id := uint32(1234)
+	InfoString = "asp1"
+	ASPIdentifier = &id
+	ntfy := make(chan *NTFY, 1)
+	HandleNotify = func(m *NTFY) { ntfy <- m }
+	defer func() { InfoString, ASPIdentifier, HandleNotify = "", nil, nil }()
+
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+
+	// ASPUP has ASP Identifier and Info String
+	if m, ok := sg.recv(t).(*codec.ASPUP); !ok {
+		t.Fatal("ASPUP is not sent")
+	} else if m.ASPIdentifier == nil || *m.ASPIdentifier != id ||
+		m.InfoString != "asp1" {
+		t.Errorf("invalid ASPUP %+v", m)
+	}
+	if m, ok := sg.recv(t).(*codec.ASPAC); !ok {
+		t.Fatal("ASPAC is not sent")
+	} else if m.InfoString != "asp1" {
+		t.Errorf("invalid ASPAC %+v", m)
+	}
+
+	// NTFY is passed to HandleNotify
+	sg.write(&codec.NTFY{
+		StatusType: 1, StatusInfo: 3, ASPIdentifier: &id, InfoString: "sg"})
+	select {
+	case m := <-ntfy:
+		if m.StatusType != 1 || m.StatusInfo != 3 ||
+			m.ASPIdentifier == nil || *m.ASPIdentifier != id ||
+			m.InfoString != "sg" {
+			t.Errorf("invalid NTFY %+v", m.NTFY)
+		}
+	case <-time.After(time.Second * 3):
+		t.Fatal("HandleNotify is not called")
+	}
+
+	if e := Inactivate(context.Background()); e != nil {
+		t.Fatalf("inactivate: %v", e)
+	}
+	if m, ok := sg.recv(t).(*codec.ASPIA); !ok {
+		t.Fatal("ASPIA is not sent")
+	} else if m.InfoString != "asp1" {
+		t.Errorf("invalid ASPIA %+v", m)
+	}
+	closeASP(t, done)
+	if m, ok := sg.recv(t).(*codec.ASPDN); !ok {
+		t.Fatal("ASPDN is not sent")
+	} else if m.InfoString != "asp1" {
+		t.Errorf("invalid ASPDN %+v", m)
+	}
+}
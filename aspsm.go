@@ -1,10 +1,9 @@
 package xua
 
 import (
-	"bytes"
-	"encoding/binary"
 	"fmt"
-	"io"
+
+	"github.com/fkgi/xua/codec"
 )
 
 /*
@@ -12,25 +11,9 @@ ASPSM: ASP State Maintenance Messages
 Message class = 0x03
 */
 
-/*
-ASPUP is ASP Up message. (Message type = 0x01)
-
-	 0                     1                   2                   3
-	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|            Tag = 0x0011       |             Length            |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|                        ASP Identifier                         |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|            Tag = 0x0004       |             Length            |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	/                          Info String                          /
-	\                                                               \
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-*/
+// ASPUP is ASP Up message.
 type ASPUP struct {
-	// id *uint32
-	// info   string
+	codec.ASPUP
 	result chan error
 }
 
@@ -43,7 +26,7 @@ func (m *ASPUP) handleMessage() {
 func (m *ASPUP) handleResult(msg message) {
 	switch res := msg.(type) {
 	case *ERR:
-		m.result <- fmt.Errorf("error with code %d", res.code)
+		m.result <- res.error()
 	case *ASPUPAck:
 		m.result <- nil
 	default:
@@ -51,39 +34,9 @@ func (m *ASPUP) handleResult(msg message) {
 	}
 }
 
-func (m *ASPUP) marshal() (uint8, uint8, []byte) {
-	buf := new(bytes.Buffer)
-
-	// ASP Identifier (Optional)
-	// if m.id != nil {
-	//	writeUint32(buf, 0x0011, *m.id)
-	// }
-
-	// Info String (Optioal)
-	// if len(m.info) != 0 {
-	// 	writeInfo(buf, m.info)
-	// }
-	return 0x03, 0x01, buf.Bytes()
-}
-
-func (m *ASPUP) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
-	return
-}
-
-/*
-ASPDN is ASP Down message. (Message type = 0x02)
-
-	 0                     1                   2                   3
-	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|           Tag = 0x0004        |             Length            |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	/                          Info String                          /
-	\                                                               \
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-*/
+// ASPDN is ASP Down message.
 type ASPDN struct {
-	// info   string
+	codec.ASPDN
 	result chan error
 }
 
@@ -96,7 +49,7 @@ func (m *ASPDN) handleMessage() {
 func (m *ASPDN) handleResult(msg message) {
 	switch res := msg.(type) {
 	case *ERR:
-		m.result <- fmt.Errorf("error with code %d", res.code)
+		m.result <- res.error()
 	case *ASPDNAck:
 		m.result <- nil
 	default:
@@ -104,79 +57,18 @@ func (m *ASPDN) handleResult(msg message) {
 	}
 }
 
-func (m *ASPDN) marshal() (uint8, uint8, []byte) {
-	// Info String (Optioal)
-	// if len(m.info) != 0 {
-	// 	writeInfo(buf, m.info)
-	// }
-	return 0x03, 0x02, []byte{}
-}
-func (m *ASPDN) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
-	return
-}
-
-/*
-BEAT is Heartbeat message. (Message type = 0x03)
-
-	 0                   1                   2                   3
-	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|           Tag = 0x0009        |             Length            |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	/                       Heartbeat Data                          /
-	\                                                               \
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-*/
+// BEAT is Heartbeat message.
 type BEAT struct {
-	tx   bool
-	data []byte
+	codec.BEAT
+	tx bool
 }
 
 func (m *BEAT) handleMessage()           {}
 func (m *BEAT) handleResult(msg message) {}
 
-func (m *BEAT) marshal() (uint8, uint8, []byte) {
-	buf := new(bytes.Buffer)
-
-	// Heartbeat Data (Optional)
-	binary.Write(buf, binary.BigEndian, uint16(0x0009))
-	binary.Write(buf, binary.BigEndian, uint16(4+len(m.data)))
-	buf.Write(m.data)
-	if len(m.data)%4 != 0 {
-		buf.Write(make([]byte, 4-len(m.data)%4))
-	}
-	return 0x03, 0x03, buf.Bytes()
-}
-
-func (m *BEAT) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
-	switch t {
-	case 0x0009:
-		// Heartbeat Data (Optional)
-		m.data = make([]byte, l)
-		_, e = r.Read(m.data)
-		if e == nil && l%4 != 0 {
-			_, e = r.Seek(int64(4-l%4), io.SeekCurrent)
-		}
-	default:
-		_, e = r.Seek(int64(l), io.SeekCurrent)
-	}
-	return
-}
-
-/*
-ASPUPAck is ASP Up Ack message. (Message type = 0x04)
-
-	 0                     1                   2                   3
-	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|            Tag = 0x0004       |             Length            |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	/                          Info String                          /
-	\                                                               \
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-*/
+// ASPUPAck is ASP Up Ack message.
 type ASPUPAck struct {
-	// info   string
+	codec.ASPUPAck
 }
 
 func (m *ASPUPAck) handleMessage() {
@@ -187,35 +79,9 @@ func (m *ASPUPAck) handleMessage() {
 }
 func (m *ASPUPAck) handleResult(msg message) {}
 
-func (m *ASPUPAck) marshal() (uint8, uint8, []byte) {
-	return 0x03, 0x04, nil
-}
-
-func (m *ASPUPAck) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
-	// switch t {
-	// case 0x0004:
-	// Info String (Optional)
-	// 	m.info, e = readInfo(r, l)
-	// default:
-	_, e = r.Seek(int64(l), io.SeekCurrent)
-	// }
-	return
-}
-
-/*
-ASPDNAck is ASP Down Ack message. (Message type = 0x05)
-
-	 0                     1                   2                   3
-	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|           Tag = 0x0004        |             Length            |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	/                          Info String                          /
-	\                                                               \
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-*/
+// ASPDNAck is ASP Down Ack message.
 type ASPDNAck struct {
-	// info   string
+	codec.ASPDNAck
 }
 
 func (m *ASPDNAck) handleMessage() {
@@ -227,65 +93,11 @@ func (m *ASPDNAck) handleMessage() {
 
 func (m *ASPDNAck) handleResult(msg message) {}
 
-func (m *ASPDNAck) marshal() (uint8, uint8, []byte) {
-	return 0x03, 0x05, nil
-}
-
-func (m *ASPDNAck) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
-	// switch t {
-	// case 0x0004:
-	// Info String (Optional)
-	// 	m.info, e = readInfo(r, l)
-	// default:
-	_, e = r.Seek(int64(l), io.SeekCurrent)
-	// }
-	return
-}
-
-/*
-BEATAck is Heartbeat Ack message. (Message type = 0x06)
-
-	 0                   1                   2                   3
-	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	|           Tag = 0x0009        |             Length            |
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-	/                       Heartbeat Data                          /
-	\                                                               \
-	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
-*/
+// BEATAck is Heartbeat Ack message.
 type BEATAck struct {
-	tx   bool
-	data []byte
+	codec.BEATAck
+	tx bool
 }
 
 func (m *BEATAck) handleMessage()           {}
 func (m *BEATAck) handleResult(msg message) {}
-
-func (m *BEATAck) marshal() (uint8, uint8, []byte) {
-	buf := new(bytes.Buffer)
-
-	// Heartbeat Data (Optional)
-	binary.Write(buf, binary.BigEndian, uint16(0x0009))
-	binary.Write(buf, binary.BigEndian, uint16(4+len(m.data)))
-	buf.Write(m.data)
-	if len(m.data)%4 != 0 {
-		buf.Write(make([]byte, 4-len(m.data)%4))
-	}
-	return 0x03, 0x06, buf.Bytes()
-}
-
-func (m *BEATAck) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
-	switch t {
-	case 0x0009:
-		// Heartbeat Data (Optional)
-		m.data = make([]byte, l)
-		_, e = r.Read(m.data)
-		if e == nil && l%4 != 0 {
-			_, e = r.Seek(int64(4-l%4), io.SeekCurrent)
-		}
-	default:
-		_, e = r.Seek(int64(l), io.SeekCurrent)
-	}
-	return
-}
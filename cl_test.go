@@ -0,0 +1,83 @@
+package xua
+
+import (
+	"testing"
+
+	"github.com/fkgi/xua/codec"
+)
+
+func TestPipeReturnOnError(t *testing.T) {
+	resetSubsystem(t)
+	q := SubsystemQueue
+	SubsystemQueue = 1
+	defer func() { SubsystemQueue = q }()
+
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	RegisterSubsystem(8, func(cgpa, cdpa SCCPAddress, b []byte) {
+		entered <- struct{}{}
+		<-release
+	})
+	RegisterSubsystem(9, func(cgpa, cdpa SCCPAddress, b []byte) {})
+	SetSubsystem(9, false)
+	defer close(release)
+
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	returned := func(m *codec.CLDT, cause uint32) {
+		t.Helper()
+		m.ReturnOnError = true
+		m.RoutingContext = []uint32{101}
+		m.HopCount = 10
+		id := uint32(1234)
+		m.CorrelationID = &id
+		sg.write(m)
+
+		r, ok := sg.recv(t).(*codec.CLDR)
+		if !ok {
+			t.Fatal("CLDR is not sent")
+		}
+		if r.Cause != cause {
+			t.Errorf("cause %#x, want %#x", r.Cause, cause)
+		}
+		if r.SourceAddress.SubsystemNumber != m.DestinationAddress.SubsystemNumber ||
+			r.SourceAddress.PointCode != m.DestinationAddress.PointCode ||
+			r.DestinationAddress.SubsystemNumber != m.SourceAddress.SubsystemNumber ||
+			r.DestinationAddress.PointCode != m.SourceAddress.PointCode {
+			t.Errorf("addresses are not swapped: cgpa=%+v cdpa=%+v",
+				r.SourceAddress, r.DestinationAddress)
+		}
+		if string(r.Data) != string(m.Data) {
+			t.Errorf("data %q is returned", r.Data)
+		}
+		if len(r.RoutingContext) != 1 || r.RoutingContext[0] != 101 ||
+			r.HopCount != 15 ||
+			r.CorrelationID == nil || *r.CorrelationID != id {
+			t.Errorf("invalid CLDR %+v", r)
+		}
+	}
+
+	// unknown SSN
+	returned(dataCLDT(10, "unknown"), codec.CauseUnequippedUser)
+	// prohibited SSN
+	returned(dataCLDT(9, "prohibited"), codec.CauseSubsystemFailure)
+	// no SSN
+	returned(dataCLDT(0, "no SSN"), codec.CauseNoTranslationForAddress)
+
+	// full queue
+	sg.write(dataCLDT(8, "handled"))
+	<-entered
+	sg.write(dataCLDT(8, "queued"))
+	returned(dataCLDT(8, "congested"), codec.CauseSubsystemCongestion)
+
+	// undeliverable data without return on error is discarded
+	sg.write(dataCLDT(10, "unknown"))
+	sg.write(dataCLDT(9, "prohibited"))
+	sg.noRecv(t)
+
+	closeASP(t, done)
+	sg.recv(t)
+}
@@ -0,0 +1,276 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+)
+
+/*
+ASPSM: ASP State Maintenance Messages
+Message class = 0x03
+*/
+
+/*
+ASPUP is ASP Up message. (Message type = 0x01)
+
+	 0                     1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|            Tag = 0x0011       |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                        ASP Identifier                         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|            Tag = 0x0004       |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ASPUP struct {
+	ASPIdentifier *uint32
+	InfoString    string
+
+	Unknown Parameters
+}
+
+func (m *ASPUP) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// ASP Identifier (Optional)
+	if m.ASPIdentifier != nil {
+		writeUint32(buf, 0x0011, *m.ASPIdentifier)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x03, 0x01, buf.Bytes(), nil
+}
+
+func (m *ASPUP) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0011:
+		// ASP Identifier (Optional)
+		m.ASPIdentifier, e = readUint32Ptr(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+ASPDN is ASP Down message. (Message type = 0x02)
+
+	 0                     1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x0004        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ASPDN struct {
+	InfoString string
+
+	Unknown Parameters
+}
+
+func (m *ASPDN) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x03, 0x02, buf.Bytes(), nil
+}
+
+func (m *ASPDN) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+BEAT is Heartbeat message. (Message type = 0x03)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x0009        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Heartbeat Data                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type BEAT struct {
+	Data []byte
+
+	Unknown Parameters
+}
+
+func (m *BEAT) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Heartbeat Data (Optional)
+	if len(m.Data) != 0 {
+		writeBytes(buf, 0x0009, m.Data)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x03, 0x03, buf.Bytes(), nil
+}
+
+func (m *BEAT) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0009:
+		// Heartbeat Data (Optional)
+		m.Data, e = readData(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+ASPUPAck is ASP Up Ack message. (Message type = 0x04)
+
+	 0                     1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|            Tag = 0x0004       |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ASPUPAck struct {
+	InfoString string
+
+	Unknown Parameters
+}
+
+func (m *ASPUPAck) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x03, 0x04, buf.Bytes(), nil
+}
+
+func (m *ASPUPAck) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+ASPDNAck is ASP Down Ack message. (Message type = 0x05)
+
+	 0                     1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x0004        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ASPDNAck struct {
+	InfoString string
+
+	Unknown Parameters
+}
+
+func (m *ASPDNAck) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x03, 0x05, buf.Bytes(), nil
+}
+
+func (m *ASPDNAck) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+BEATAck is Heartbeat Ack message. (Message type = 0x06)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x0009        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Heartbeat Data                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type BEATAck struct {
+	Data []byte
+
+	Unknown Parameters
+}
+
+func (m *BEATAck) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Heartbeat Data (Optional)
+	if len(m.Data) != 0 {
+		writeBytes(buf, 0x0009, m.Data)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x03, 0x06, buf.Bytes(), nil
+}
+
+func (m *BEATAck) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0009:
+		// Heartbeat Data (Optional)
+		m.Data, e = readData(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
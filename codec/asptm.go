@@ -0,0 +1,338 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+/*
+ASPTM: ASP Traffic Maintenance Messages
+Message class = 0x04
+*/
+
+// Traffic Mode Type values.
+const (
+	Override  uint32 = 1
+	Loadshare uint32 = 2
+	Broadcast uint32 = 3
+)
+
+// Label is TID Label or DRN Label parameter.
+// Start and End are bit position of the label in TID or DRN.
+type Label struct {
+	Start uint8
+	End   uint8
+	Value uint16
+}
+
+func writeLabel(w io.Writer, t uint16, v *Label) {
+	binary.Write(w, binary.BigEndian, t)
+	binary.Write(w, binary.BigEndian, uint16(8))
+	w.Write([]byte{v.Start, v.End})
+	binary.Write(w, binary.BigEndian, v.Value)
+}
+
+func readLabel(r io.ReadSeeker, l uint16) (v *Label, e error) {
+	if l != 4 {
+		e = ErrBadLength
+		return
+	}
+	b := make([]byte, 4)
+	if e = readFull(r, b); e == nil {
+		v = &Label{
+			Start: b[0],
+			End:   b[1],
+			Value: binary.BigEndian.Uint16(b[2:])}
+	}
+	return
+}
+
+/*
+ASPAC is ASP Active message. (Message type = 0x01)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x000B        |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                       Traffic Mode Type                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x0006        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0110         |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|     start     |      end      |        TID label value        |
+	+-------------------------------+-------------------------------+
+	|          Tag = 0x010F         |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|     start     |      end      |        DRN label value        |
+	+-------------------------------+-------------------------------+
+	|           Tag = 0x0004        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ASPAC struct {
+	TrafficMode       uint32
+	RoutingContext    []uint32
+	NetworkAppearance *uint32
+	TIDLabel          *Label
+	DRNLabel          *Label
+	InfoString        string
+
+	Unknown Parameters
+}
+
+func (m *ASPAC) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Traffic Mode Type (Optional)
+	if m.TrafficMode != 0 {
+		writeUint32(buf, 0x000B, m.TrafficMode)
+	}
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// TID Label (Optional)
+	if m.TIDLabel != nil {
+		writeLabel(buf, 0x0110, m.TIDLabel)
+	}
+
+	// DRN Label (Optional)
+	if m.DRNLabel != nil {
+		writeLabel(buf, 0x010F, m.DRNLabel)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x04, 0x01, buf.Bytes(), nil
+}
+
+func (m *ASPAC) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x000B:
+		// Traffic Mode Type (Optional)
+		m.TrafficMode, e = readUint32(r, l)
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0110:
+		// TID Label (Optional)
+		m.TIDLabel, e = readLabel(r, l)
+	case 0x010F:
+		// DRN Label (Optional)
+		m.DRNLabel, e = readLabel(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+ASPIA is ASP Inactive message. (Message type = 0x02)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x0006        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x0004        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          INFO String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ASPIA struct {
+	RoutingContext []uint32
+	InfoString     string
+
+	Unknown Parameters
+}
+
+func (m *ASPIA) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x04, 0x02, buf.Bytes(), nil
+}
+
+func (m *ASPIA) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+ASPACAck is ASP Active Ack message. (Message type = 0x03)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x000B        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                       Traffic Mode Type                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Tag = 0x0006        |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                     * Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0004         |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ASPACAck struct {
+	TrafficMode    uint32
+	RoutingContext []uint32
+	InfoString     string
+
+	Unknown Parameters
+}
+
+func (m *ASPACAck) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Traffic Mode Type (Optional)
+	if m.TrafficMode != 0 {
+		writeUint32(buf, 0x000B, m.TrafficMode)
+	}
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x04, 0x03, buf.Bytes(), nil
+}
+
+func (m *ASPACAck) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x000B:
+		// Traffic Mode Type (Optional)
+		m.TrafficMode, e = readUint32(r, l)
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+ASPIAAck is ASP Inactive Ack message. (Message type = 0x04)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0006         |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0004         |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ASPIAAck struct {
+	RoutingContext []uint32
+	InfoString     string
+
+	Unknown Parameters
+}
+
+func (m *ASPIAAck) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x04, 0x04, buf.Bytes(), nil
+}
+
+func (m *ASPIAAck) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
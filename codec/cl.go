@@ -0,0 +1,803 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/netip"
+	"strings"
+)
+
+/*
+CL: SCCP Connectionless (CL) Messages
+Message class = 0x07
+*/
+
+/*
+CLDT is Connectionless Data Transfer message. (Message type = 0x01)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0006         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                     * Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0115          |             Length = 8        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|              Reserved                         | *Protocol Cl. |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0102          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      * Source Address                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0103          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                   * Destination Address                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0116          |             Length = 8        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      * Sequence  Control                      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0101          |             Length = 8        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|              Reserved                         | SS7 Hop Count |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |             Length = 8        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                Reserved                       |   Importance  |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0114          |             Length = 8        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|              Reserved                         |  Msg Priority |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0013          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         Correlation ID                        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0117          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	| first/remain  |             Segmentation Reference            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010B          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                           * Data                              /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type CLDT struct {
+	RoutingContext     []uint32
+	NetworkAppearance  *uint32
+	ProtocolClass      uint8
+	ReturnOnError      bool
+	SourceAddress      SCCPAddress
+	DestinationAddress SCCPAddress
+	SequenceControl    uint32
+
+	HopCount        uint8
+	Importance      *uint8
+	MessagePriority *uint8
+	CorrelationID   *uint32
+	Segmentation    *Segmentation
+
+	Data []byte
+
+	Unknown Parameters
+}
+
+func (m *CLDT) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// Protocol Class
+	if m.ReturnOnError {
+		writeUint8(buf, 0x0115, m.ProtocolClass|0x80)
+	} else {
+		writeUint8(buf, 0x0115, m.ProtocolClass)
+	}
+
+	// Source Address
+	if e := m.SourceAddress.marshal(buf, 0x0102); e != nil {
+		return 0, 0, nil, e
+	}
+
+	// Destination Address
+	if e := m.DestinationAddress.marshal(buf, 0x0103); e != nil {
+		return 0, 0, nil, e
+	}
+
+	// Sequence Control
+	writeUint32(buf, 0x0116, m.SequenceControl)
+
+	// SS7 Hop Count (Optional)
+	if m.HopCount != 0 {
+		writeUint8(buf, 0x0101, m.HopCount)
+	}
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Message Priority (Optional)
+	if m.MessagePriority != nil {
+		writeUint8(buf, 0x0114, *m.MessagePriority)
+	}
+
+	// Correlation ID (Optional)
+	if m.CorrelationID != nil {
+		writeUint32(buf, 0x0013, *m.CorrelationID)
+	}
+
+	// Segmentation (Optional)
+	if m.Segmentation != nil {
+		m.Segmentation.marshal(buf)
+	}
+
+	// Data
+	writeData(buf, m.Data)
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x07, 0x01, buf.Bytes(), nil
+}
+
+func (m *CLDT) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0115:
+		// Protocol Class
+		m.ProtocolClass, e = readUint8(r, l)
+		m.ReturnOnError = m.ProtocolClass&0x80 == 0x80
+		m.ProtocolClass = m.ProtocolClass & 0x7F
+	case 0x0102:
+		// Source Address
+		m.SourceAddress, e = readAddress(r, l)
+	case 0x0103:
+		// Destination Address
+		m.DestinationAddress, e = readAddress(r, l)
+	case 0x0116:
+		// Sequence Control
+		m.SequenceControl, e = readUint32(r, l)
+	case 0x0101:
+		// SS7 Hop Count (Optional)
+		m.HopCount, e = readUint8(r, l)
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	case 0x0114:
+		// Message Priority (Optional)
+		m.MessagePriority, e = readUint8Ptr(r, l)
+	case 0x0013:
+		// Correlation ID (Optional)
+		m.CorrelationID, e = readUint32Ptr(r, l)
+	case 0x0117:
+		// Segmentation (Optional)
+		m.Segmentation, e = readSegmentation(r, l)
+	case 0x010B:
+		// Data
+		m.Data, e = readData(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+CLDR is Connectionless Data Response message. (Message type = 0x02)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0006         |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                     * Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0106          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         * SCCP Cause                          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0102          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      * Source Address                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0103          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                   * Destination Address                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0101          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|              Reserved                         | SS7 Hop Count |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                Reserved                       |   Importance  |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0114          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|              Reserved                         |  Msg Priority |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0013          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         Correlation ID                        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0117          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	| first/remain  |             Segmentation Reference            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010b          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-
+	/                             Data                              /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type CLDR struct {
+	RoutingContext     []uint32
+	NetworkAppearance  *uint32
+	Cause              uint32
+	SourceAddress      SCCPAddress
+	DestinationAddress SCCPAddress
+
+	HopCount        uint8
+	Importance      *uint8
+	MessagePriority *uint8
+	CorrelationID   *uint32
+	Segmentation    *Segmentation
+
+	Data []byte
+
+	Unknown Parameters
+}
+
+// SCCP Cause values of Return Cause type.
+const (
+	CauseNoTranslationForNature   uint32 = 0x0100
+	CauseNoTranslationForAddress  uint32 = 0x0101
+	CauseSubsystemCongestion      uint32 = 0x0102
+	CauseSubsystemFailure         uint32 = 0x0103
+	CauseUnequippedUser           uint32 = 0x0104
+	CauseMTPFailure               uint32 = 0x0105
+	CauseNetworkCongestion        uint32 = 0x0106
+	CauseUnqualified              uint32 = 0x0107
+	CauseErrorInMessageTransport  uint32 = 0x0108
+	CauseErrorInLocalProcessing   uint32 = 0x0109
+	CauseCannotPerformReassembly  uint32 = 0x010A
+	CauseSCCPFailure              uint32 = 0x010B
+	CauseHopCounterViolation      uint32 = 0x010C
+	CauseSegmentationNotSupported uint32 = 0x010D
+	CauseSegmentationFailure      uint32 = 0x010E
+)
+
+func (m *CLDR) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// SCCP Cause
+	writeUint32(buf, 0x0106, m.Cause)
+
+	// Source Address
+	if e := m.SourceAddress.marshal(buf, 0x0102); e != nil {
+		return 0, 0, nil, e
+	}
+
+	// Destination Address
+	if e := m.DestinationAddress.marshal(buf, 0x0103); e != nil {
+		return 0, 0, nil, e
+	}
+
+	// SS7 Hop Count (Optional)
+	if m.HopCount != 0 {
+		writeUint8(buf, 0x0101, m.HopCount)
+	}
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Message Priority (Optional)
+	if m.MessagePriority != nil {
+		writeUint8(buf, 0x0114, *m.MessagePriority)
+	}
+
+	// Correlation ID (Optional)
+	if m.CorrelationID != nil {
+		writeUint32(buf, 0x0013, *m.CorrelationID)
+	}
+
+	// Segmentation (Optional)
+	if m.Segmentation != nil {
+		m.Segmentation.marshal(buf)
+	}
+
+	// Data (Optional)
+	if len(m.Data) != 0 {
+		writeData(buf, m.Data)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x07, 0x02, buf.Bytes(), nil
+}
+
+func (m *CLDR) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0106:
+		// SCCP Cause
+		m.Cause, e = readUint32(r, l)
+	case 0x0102:
+		// Source Address
+		m.SourceAddress, e = readAddress(r, l)
+	case 0x0103:
+		// Destination Address
+		m.DestinationAddress, e = readAddress(r, l)
+	case 0x0101:
+		// SS7 Hop Count (Optional)
+		m.HopCount, e = readUint8(r, l)
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	case 0x0114:
+		// Message Priority (Optional)
+		m.MessagePriority, e = readUint8Ptr(r, l)
+	case 0x0013:
+		// Correlation ID (Optional)
+		m.CorrelationID, e = readUint32Ptr(r, l)
+	case 0x0117:
+		// Segmentation (Optional)
+		m.Segmentation, e = readSegmentation(r, l)
+	case 0x010B:
+		// Data (Optional)
+		m.Data, e = readData(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+Segmentation is Segmentation parameter.
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0117          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	| first/remain  |             Segmentation Reference            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type Segmentation struct {
+	First     bool
+	Remain    uint8  // 4 bits
+	Reference uint32 // 24 bits
+}
+
+func (s *Segmentation) marshal(w io.Writer) {
+	v := uint32(s.Remain&0x0f)<<24 | s.Reference&0x00ffffff
+	if s.First {
+		v |= 0x80000000
+	}
+	writeUint32(w, 0x0117, v)
+}
+
+func readSegmentation(r io.ReadSeeker, l uint16) (s *Segmentation, e error) {
+	var v uint32
+	if v, e = readUint32(r, l); e == nil {
+		s = &Segmentation{
+			First:     v&0x80000000 != 0,
+			Remain:    uint8(v>>24) & 0x0f,
+			Reference: v & 0x00ffffff}
+	}
+	return
+}
+
+/*
+SCCPAddress is address of SCCP
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|      Routing Indicator        |       Address Indicator       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Address parameter(s)                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+Global Title
+
+	0                 1                   2                   3
+	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8001          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                Reserved                       |      GTI      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|   No. Digits  | Trans. type   |    Num. Plan  | Nature of Add |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|2 addr.|1 addr.|4 addr.|3 addr.|6 addr.|5 addr.|8 addr.|7 addr.|
+	|  sig. | sig.  |  sig. | sig.  |  sig. | sig.  |  sig. | sig.  |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|        .............          |filler |N addr.|   filler      |
+	|                               |if req | sig.  |               |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+Point Code
+
+	0                   1                   2                   3
+	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8002          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                            Point Code                         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+Subsystem Number
+
+	0                   1                   2                   3
+	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8003          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                 Reserved                      |   SSN value   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+IPv4 Address
+
+	0                   1                   2                   3
+	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8004          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         IPv4 Address                          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+Hostname
+
+	0                   1                   2                   3
+	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8005          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                           Host Name                           /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+IPv6 Address
+
+	0                   1                   2                   3
+	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8006          |            Length = 20        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	\                                                               \
+	/                         IPv6 Address                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type SCCPAddress struct {
+	RoutingIndicator
+	AddressIndicator
+
+	GlobalTitleIndicator
+	TranslationType uint8
+	NumberingPlan
+	NatureOfAddress
+	GlobalTitle string
+
+	PointCode       PointCode
+	SubsystemNumber uint8
+
+	// IPAddress is IPv4 or IPv6 address. It is not sent if invalid.
+	IPAddress netip.Addr
+	Hostname  string
+
+	Unknown Parameters
+}
+
+// RoutingIndicator is Routing Indicator of SCCP address.
+// Zero value means Route on SSN + PC if PC and SSN are present,
+// Route on Hostname or Route on SSN + IP if GT is not present
+// and Hostname or IP address and SSN are present,
+// otherwise Route on GT.
+type RoutingIndicator uint16
+
+const (
+	RI_GT       RoutingIndicator = 1 // Route on Global Title
+	RI_SSNPC    RoutingIndicator = 2 // Route on SSN + PC
+	RI_Hostname RoutingIndicator = 3 // Route on Hostname
+	RI_SSNIP    RoutingIndicator = 4 // Route on SSN + IP Address
+)
+
+// AddressIndicator is Address Indicator of SCCP address.
+// Zero value means the present parameters are included.
+type AddressIndicator uint16
+
+const (
+	AI_SSN AddressIndicator = 0x01 // Include SSN
+	AI_PC  AddressIndicator = 0x02 // Include PC
+	AI_GT  AddressIndicator = 0x04 // Include GT
+)
+
+// GlobalTitleIndicator is GTI of Global Title.
+// Zero value means GTI_TTNPINAI.
+type GlobalTitleIndicator uint8
+
+const (
+	GTI_NAI      GlobalTitleIndicator = 1 // NAI only
+	GTI_TT       GlobalTitleIndicator = 2 // Translation Type only
+	GTI_TTNPI    GlobalTitleIndicator = 3 // TT, NPI and Encoding Scheme
+	GTI_TTNPINAI GlobalTitleIndicator = 4 // TT, NPI, Encoding Scheme and NAI
+)
+
+type NumberingPlan uint8
+
+const (
+	NPI_Unknown NumberingPlan = 0
+	NPI_E164    NumberingPlan = 1
+	NPI_Generic NumberingPlan = 2
+	NPI_X121    NumberingPlan = 3
+	NPI_F69     NumberingPlan = 4
+	NPI_E211    NumberingPlan = 5
+	NPI_E212    NumberingPlan = 6
+	NPI_E214    NumberingPlan = 7
+	NPI_Private NumberingPlan = 14
+)
+
+type NatureOfAddress uint8
+
+const (
+	NAI_Unknown             NatureOfAddress = 0
+	NAI_Subscriber          NatureOfAddress = 1
+	NAI_NationalUse         NatureOfAddress = 2
+	NAI_NationalSignificant NatureOfAddress = 3
+	NAI_International       NatureOfAddress = 4
+)
+
+// gtDigits is address signal of Global Title.
+const gtDigits = "0123456789ABCDEF"
+
+// ErrInvalidGlobalTitle is error of Global Title with non hex digit.
+var ErrInvalidGlobalTitle = errors.New("invalid global title")
+
+// Validate returns ErrInvalidGlobalTitle if GlobalTitle has
+// character other than hex digits, or its length exceeds 255.
+func (a *SCCPAddress) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if len(a.GlobalTitle) > 255 {
+		return ErrInvalidGlobalTitle
+	}
+	for _, c := range []byte(strings.ToUpper(a.GlobalTitle)) {
+		if strings.IndexByte(gtDigits, c) < 0 {
+			return ErrInvalidGlobalTitle
+		}
+	}
+	return nil
+}
+
+// marshal writes a as parameter id.
+// It returns ErrInvalidGlobalTitle if GlobalTitle can not be encoded.
+func (a *SCCPAddress) marshal(w io.Writer, id uint16) error {
+	buf := new(bytes.Buffer)
+
+	var ai AddressIndicator
+	if len(a.GlobalTitle) > 255 {
+		return ErrInvalidGlobalTitle
+	}
+	if len(a.GlobalTitle) != 0 {
+		l := (len(a.GlobalTitle) + 1) / 2
+		if l%4 != 0 {
+			l += 4 - l%4
+		}
+
+		gti := a.GlobalTitleIndicator
+		if gti == 0 {
+			gti = GTI_TTNPINAI
+		}
+
+		binary.Write(buf, binary.BigEndian, uint16(0x8001))
+		binary.Write(buf, binary.BigEndian, uint16(l+12))
+		binary.Write(buf, binary.BigEndian, uint32(gti))
+		buf.WriteByte(uint8(len(a.GlobalTitle)))
+		buf.WriteByte(a.TranslationType)
+		buf.WriteByte(byte(a.NumberingPlan))
+		buf.WriteByte(byte(a.NatureOfAddress))
+
+		// odd digits are filled with 0
+		digits := make([]byte, l)
+		for i, c := range []byte(strings.ToUpper(a.GlobalTitle)) {
+			d := strings.IndexByte(gtDigits, c)
+			if d < 0 {
+				return ErrInvalidGlobalTitle
+			}
+			digits[i/2] |= byte(d) << (4 * (i % 2))
+		}
+		buf.Write(digits)
+		ai |= AI_GT
+	}
+	if a.PointCode.Value != 0 {
+		writeUint32(buf, 0x8002, a.PointCode.Value)
+		ai |= AI_PC
+	}
+	if a.SubsystemNumber != 0 {
+		writeUint8(buf, 0x8003, a.SubsystemNumber)
+		ai |= AI_SSN
+	}
+	if a.IPAddress.Is4() {
+		ip := a.IPAddress.As4()
+		writeBytes(buf, 0x8004, ip[:])
+	} else if a.IPAddress.IsValid() {
+		ip := a.IPAddress.As16()
+		writeBytes(buf, 0x8006, ip[:])
+	}
+	if len(a.Hostname) != 0 {
+		// null terminated
+		writeBytes(buf, 0x8005, append([]byte(a.Hostname), 0))
+	}
+	a.Unknown.marshal(buf)
+	if a.AddressIndicator != 0 {
+		ai = a.AddressIndicator
+	}
+
+	ri := a.RoutingIndicator
+	switch {
+	case ri != 0:
+	case a.PointCode.Value != 0 && a.SubsystemNumber != 0:
+		ri = RI_SSNPC
+	case len(a.GlobalTitle) == 0 && len(a.Hostname) != 0:
+		ri = RI_Hostname
+	case len(a.GlobalTitle) == 0 &&
+		a.IPAddress.IsValid() && a.SubsystemNumber != 0:
+		ri = RI_SSNIP
+	default:
+		ri = RI_GT
+	}
+
+	binary.Write(w, binary.BigEndian, id)
+	binary.Write(w, binary.BigEndian, uint16(8+buf.Len()))
+	binary.Write(w, binary.BigEndian, ri)
+	binary.Write(w, binary.BigEndian, ai)
+	buf.WriteTo(w)
+	return nil
+}
+
+func readAddress(r io.ReadSeeker, l uint16) (a SCCPAddress, e error) {
+	if l < 4 || l%4 != 0 {
+		e = ErrBadLength
+		return
+	}
+	buf := make([]byte, l)
+	if e = readFull(r, buf); e != nil {
+		return
+	}
+	a.RoutingIndicator = RoutingIndicator(binary.BigEndian.Uint16(buf[0:]))
+	a.AddressIndicator = AddressIndicator(binary.BigEndian.Uint16(buf[2:]))
+
+	rr := bytes.NewReader(buf[4:])
+	for rr.Len() != 0 {
+		if rr.Len() < 4 {
+			e = ErrTruncated
+			return
+		}
+		var t, l uint16
+		binary.Read(rr, binary.BigEndian, &t)
+		binary.Read(rr, binary.BigEndian, &l)
+		if l < 4 || int(l-4) > rr.Len() {
+			e = ErrBadLength
+			return
+		}
+		l -= 4
+		next := int64(len(buf)-4-rr.Len()) + int64(l)
+		if l%4 != 0 {
+			next += int64(4 - l%4)
+		}
+
+		switch t {
+		case 0x8001:
+			// GT
+			if l < 8 {
+				e = ErrBadLength
+				return
+			}
+			gthdr := make([]byte, 8)
+			rr.Read(gthdr)
+			a.GlobalTitleIndicator = GlobalTitleIndicator(gthdr[3])
+			a.TranslationType = gthdr[5]
+			a.NumberingPlan = NumberingPlan(gthdr[6])
+			a.NatureOfAddress = NatureOfAddress(gthdr[7])
+
+			digits := make([]byte, l-8)
+			rr.Read(digits)
+			if int(gthdr[4]) > len(digits)*2 {
+				e = ErrBadLength
+				return
+			}
+			gt := make([]byte, gthdr[4])
+			for i := range gt {
+				gt[i] = gtDigits[digits[i/2]>>(4*(i%2))&0x0F]
+			}
+			a.GlobalTitle = string(gt)
+		case 0x8002:
+			// PC
+			a.PointCode.Variant = DefaultPointCodeVariant
+			a.PointCode.Value, e = readUint32(rr, l)
+		case 0x8003:
+			// SSN
+			a.SubsystemNumber, e = readUint8(rr, l)
+		case 0x8004:
+			// IPv4
+			var ip [4]byte
+			if l != 4 {
+				e = ErrBadLength
+			} else if e = readFull(rr, ip[:]); e == nil {
+				a.IPAddress = netip.AddrFrom4(ip)
+			}
+		case 0x8005:
+			// Hostname
+			var v []byte
+			if v, e = readData(rr, l); e == nil {
+				a.Hostname = strings.TrimRight(string(v), "\x00")
+			}
+		case 0x8006:
+			// IPv6
+			var ip [16]byte
+			if l != 16 {
+				e = ErrBadLength
+			} else if e = readFull(rr, ip[:]); e == nil {
+				a.IPAddress = netip.AddrFrom16(ip)
+			}
+		default:
+			e = a.Unknown.unmarshal(t, l, rr)
+		}
+		if e != nil {
+			return
+		}
+		if next > int64(len(buf)-4) {
+			// last padding is omitted
+			break
+		}
+		rr.Seek(next, io.SeekStart)
+	}
+	return
+}
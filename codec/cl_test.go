@@ -0,0 +1,200 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestGlobalTitleRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   SCCPAddress
+		ri   RoutingIndicator
+		ai   AddressIndicator
+		gti  GlobalTitleIndicator
+		gt   string
+	}{
+		{
+			name: "odd digits",
+			in: SCCPAddress{
+				RoutingIndicator:     RI_GT,
+				AddressIndicator:     AI_GT | AI_SSN,
+				GlobalTitleIndicator: GTI_TTNPINAI,
+				TranslationType:      0x05,
+				NumberingPlan:        NPI_E164,
+				NatureOfAddress:      NAI_International,
+				GlobalTitle:          "8190123",
+				SubsystemNumber:      0x06},
+			ri: RI_GT, ai: AI_GT | AI_SSN, gti: GTI_TTNPINAI, gt: "8190123"},
+		{
+			name: "hex digits",
+			in: SCCPAddress{
+				GlobalTitleIndicator: GTI_NAI,
+				NatureOfAddress:      NAI_International,
+				GlobalTitle:          "81AbCdEf0",
+				PointCode:            PointCode{Value: 1234},
+				SubsystemNumber:      0x07},
+			ri: RI_SSNPC, ai: AI_GT | AI_PC | AI_SSN, gti: GTI_NAI, gt: "81ABCDEF0"},
+		{
+			name: "default GTI",
+			in: SCCPAddress{
+				TranslationType: 0x01,
+				GlobalTitle:     "1234"},
+			ri: RI_GT, ai: AI_GT, gti: GTI_TTNPINAI, gt: "1234"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, e := Marshal(&CLDT{SourceAddress: tc.in, Data: []byte{0}})
+			if e != nil {
+				t.Fatal(e)
+			}
+			m, e := Unmarshal(b)
+			if e != nil {
+				t.Fatal(e)
+			}
+			a := m.(*CLDT).SourceAddress
+
+			if a.RoutingIndicator != tc.ri || a.AddressIndicator != tc.ai ||
+				a.GlobalTitleIndicator != tc.gti {
+				t.Errorf("RI=%d AI=%d GTI=%d", a.RoutingIndicator,
+					a.AddressIndicator, a.GlobalTitleIndicator)
+			}
+			if a.TranslationType != tc.in.TranslationType ||
+				a.NumberingPlan != tc.in.NumberingPlan ||
+				a.NatureOfAddress != tc.in.NatureOfAddress {
+				t.Errorf("TT=%d NP=%d NAI=%d",
+					a.TranslationType, a.NumberingPlan, a.NatureOfAddress)
+			}
+			if a.GlobalTitle != tc.gt {
+				t.Errorf("GT=%s", a.GlobalTitle)
+			}
+			if a.PointCode.Value != tc.in.PointCode.Value ||
+				a.SubsystemNumber != tc.in.SubsystemNumber {
+				t.Errorf("PC=%d SSN=%d", a.PointCode.Value, a.SubsystemNumber)
+			}
+		})
+	}
+}
+
+func TestInvalidGlobalTitle(t *testing.T) {
+	for _, gt := range []string{"12X4", "+8190", "81 90", "12G", strings.Repeat("1", 256)} {
+		a := SCCPAddress{GlobalTitle: gt}
+		for _, m := range []Message{
+			&CLDT{DestinationAddress: a, Data: []byte{0}},
+			&CLDR{SourceAddress: a},
+			&CORE{SourceAddress: &a},
+			&COREF{DestinationAddress: &a},
+			&REGREQ{RoutingKey: []RoutingKey{{SourceAddress: []SCCPAddress{a}}}},
+		} {
+			if _, e := Marshal(m); !errors.Is(e, ErrInvalidGlobalTitle) {
+				t.Errorf("%q in %T: %v", gt, m, e)
+			}
+		}
+
+		// address is not written with wrong digits
+		buf := new(bytes.Buffer)
+		if e := a.marshal(buf, 0x0103); !errors.Is(e, ErrInvalidGlobalTitle) || buf.Len() != 0 {
+			t.Errorf("%q: %v, % x", gt, e, buf.Bytes())
+		}
+		if e := a.Validate(); !errors.Is(e, ErrInvalidGlobalTitle) {
+			t.Errorf("%q: validate %v", gt, e)
+		}
+	}
+}
+
+// addressParam returns SCCP address parameter with value b.
+func addressParam(ri RoutingIndicator, ai AddressIndicator, b ...byte) []byte {
+	v := []byte{0, byte(ri), 0, byte(ai)}
+	return append(v, b...)
+}
+
+func TestIPAddressRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   SCCPAddress
+		ri   RoutingIndicator
+		tag  uint16
+		plen int
+	}{
+		{"IPv4", SCCPAddress{
+			IPAddress: netip.MustParseAddr("192.0.2.1"), SubsystemNumber: 6},
+			RI_SSNIP, 0x8004, 8},
+		{"IPv6", SCCPAddress{
+			IPAddress: netip.MustParseAddr("2001:db8::1"), SubsystemNumber: 6},
+			RI_SSNIP, 0x8006, 20},
+		{"IPv4 mapped IPv6", SCCPAddress{
+			IPAddress: netip.MustParseAddr("::ffff:192.0.2.1"), SubsystemNumber: 6},
+			RI_SSNIP, 0x8006, 20},
+		{"hostname 1", SCCPAddress{Hostname: "a"}, RI_Hostname, 0x8005, 6},
+		{"hostname 2", SCCPAddress{Hostname: "ab"}, RI_Hostname, 0x8005, 7},
+		{"hostname 3", SCCPAddress{Hostname: "abc"}, RI_Hostname, 0x8005, 8},
+		{"hostname 4", SCCPAddress{Hostname: "abcd"}, RI_Hostname, 0x8005, 9},
+		{"hostname with SSN", SCCPAddress{
+			Hostname: "sg1.example.net", SubsystemNumber: 6},
+			RI_Hostname, 0x8005, 20},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if e := tc.in.marshal(buf, 0x0102); e != nil {
+				t.Fatal(e)
+			}
+			b := buf.Bytes()
+			if len(b)%4 != 0 || int(binary.BigEndian.Uint16(b[2:])) != len(b) {
+				t.Fatalf("invalid length % x", b)
+			}
+
+			// parameter is padded with zero
+			v := b[8:]
+			for len(v) >= 4 && binary.BigEndian.Uint16(v) != tc.tag {
+				v = v[(int(binary.BigEndian.Uint16(v[2:]))+3)&^3:]
+			}
+			if len(v) < 4 {
+				t.Fatalf("no parameter 0x%04x in % x", tc.tag, b)
+			}
+			l := int(binary.BigEndian.Uint16(v[2:]))
+			if l != tc.plen || len(v) < (l+3)&^3 {
+				t.Fatalf("invalid parameter % x", v)
+			}
+			for _, p := range v[l : (l+3)&^3] {
+				if p != 0 {
+					t.Errorf("invalid padding % x", v)
+				}
+			}
+
+			a, e := readAddress(bytes.NewReader(b[4:]), uint16(len(b)-4))
+			if e != nil {
+				t.Fatal(e)
+			}
+			if a.RoutingIndicator != tc.ri ||
+				a.IPAddress != tc.in.IPAddress ||
+				a.Hostname != tc.in.Hostname ||
+				a.SubsystemNumber != tc.in.SubsystemNumber {
+				t.Errorf("invalid address %+v", a)
+			}
+		})
+	}
+}
+
+func TestInvalidIPAddress(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		v    []byte
+		err  error
+	}{
+		{"short IPv4", addressParam(RI_SSNIP, 0,
+			0x80, 0x04, 0x00, 0x07, 192, 0, 2, 0x00), ErrBadLength},
+		{"long IPv4", addressParam(RI_SSNIP, 0,
+			0x80, 0x04, 0x00, 0x0c, 192, 0, 2, 1, 0, 0, 0, 0), ErrBadLength},
+		{"short IPv6", addressParam(RI_SSNIP, 0,
+			0x80, 0x06, 0x00, 0x08, 0x20, 0x01, 0x0d, 0xb8), ErrBadLength},
+		{"truncated IPv6", addressParam(RI_SSNIP, 0,
+			0x80, 0x06, 0x00, 0x14, 0x20, 0x01, 0x0d, 0xb8), ErrBadLength},
+	} {
+		if a, e := readAddress(bytes.NewReader(tc.v), uint16(len(tc.v))); !errors.Is(e, tc.err) {
+			t.Errorf("%s: %+v, %v", tc.name, a, e)
+		}
+	}
+}
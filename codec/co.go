@@ -0,0 +1,1097 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+)
+
+/*
+CO: SCCP Connection-Oriented Messages
+Message class = 0x08
+*/
+
+/*
+CORE is Connection Request message. (Message type = 0x01)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0115          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |* Protocol Cl. |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0104          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   * Source Reference Number                   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0103          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                     * Destination Address                     /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0116          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      * Sequence Control                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0101          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    | SS7 Hop Count |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0102          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                        Source Address                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010A          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |    Credit     |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |  Importance   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010B          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                             Data                              /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type CORE struct {
+	RoutingContext     []uint32
+	ProtocolClass      uint8
+	SourceReference    uint32
+	DestinationAddress SCCPAddress
+	SequenceControl    uint32
+	HopCount           uint8
+	SourceAddress      *SCCPAddress
+	Credit             *uint8
+	Importance         *uint8
+	Data               []byte
+
+	Unknown Parameters
+}
+
+func (m *CORE) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Protocol Class
+	writeUint8(buf, 0x0115, m.ProtocolClass)
+
+	// Source Reference Number
+	writeUint32(buf, 0x0104, m.SourceReference)
+
+	// Destination Address
+	if e := m.DestinationAddress.marshal(buf, 0x0103); e != nil {
+		return 0, 0, nil, e
+	}
+
+	// Sequence Control
+	writeUint32(buf, 0x0116, m.SequenceControl)
+
+	// SS7 Hop Count (Optional)
+	if m.HopCount != 0 {
+		writeUint8(buf, 0x0101, m.HopCount)
+	}
+
+	// Source Address (Optional)
+	if m.SourceAddress != nil {
+		if e := m.SourceAddress.marshal(buf, 0x0102); e != nil {
+			return 0, 0, nil, e
+		}
+	}
+
+	// Credit (Optional)
+	if m.Credit != nil {
+		writeUint8(buf, 0x010A, *m.Credit)
+	}
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Data (Optional)
+	if len(m.Data) != 0 {
+		writeData(buf, m.Data)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x01, buf.Bytes(), nil
+}
+
+func (m *CORE) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0115:
+		// Protocol Class
+		m.ProtocolClass, e = readUint8(r, l)
+	case 0x0104:
+		// Source Reference Number
+		m.SourceReference, e = readUint32(r, l)
+	case 0x0103:
+		// Destination Address
+		m.DestinationAddress, e = readAddress(r, l)
+	case 0x0116:
+		// Sequence Control
+		m.SequenceControl, e = readUint32(r, l)
+	case 0x0101:
+		// SS7 Hop Count (Optional)
+		m.HopCount, e = readUint8(r, l)
+	case 0x0102:
+		// Source Address (Optional)
+		var a SCCPAddress
+		if a, e = readAddress(r, l); e == nil {
+			m.SourceAddress = &a
+		}
+	case 0x010A:
+		// Credit (Optional)
+		m.Credit, e = readUint8Ptr(r, l)
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	case 0x010B:
+		// Data (Optional)
+		m.Data, e = readData(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+COAK is Connection Acknowledge message. (Message type = 0x02)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0115          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |* Protocol Cl. |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0104          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   * Source Reference Number                   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0116          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      * Sequence Control                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010A          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |    Credit     |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0103          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      Destination Address                      /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |  Importance   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010B          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                             Data                              /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type COAK struct {
+	RoutingContext       []uint32
+	ProtocolClass        uint8
+	DestinationReference uint32
+	SourceReference      uint32
+	SequenceControl      uint32
+	Credit               *uint8
+	DestinationAddress   *SCCPAddress
+	Importance           *uint8
+	Data                 []byte
+
+	Unknown Parameters
+}
+
+func (m *COAK) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Protocol Class
+	writeUint8(buf, 0x0115, m.ProtocolClass)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// Source Reference Number
+	writeUint32(buf, 0x0104, m.SourceReference)
+
+	// Sequence Control
+	writeUint32(buf, 0x0116, m.SequenceControl)
+
+	// Credit (Optional)
+	if m.Credit != nil {
+		writeUint8(buf, 0x010A, *m.Credit)
+	}
+
+	// Destination Address (Optional)
+	if m.DestinationAddress != nil {
+		if e := m.DestinationAddress.marshal(buf, 0x0103); e != nil {
+			return 0, 0, nil, e
+		}
+	}
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Data (Optional)
+	if len(m.Data) != 0 {
+		writeData(buf, m.Data)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x02, buf.Bytes(), nil
+}
+
+func (m *COAK) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0115:
+		// Protocol Class
+		m.ProtocolClass, e = readUint8(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0104:
+		// Source Reference Number
+		m.SourceReference, e = readUint32(r, l)
+	case 0x0116:
+		// Sequence Control
+		m.SequenceControl, e = readUint32(r, l)
+	case 0x010A:
+		// Credit (Optional)
+		m.Credit, e = readUint8Ptr(r, l)
+	case 0x0103:
+		// Destination Address (Optional)
+		var a SCCPAddress
+		if a, e = readAddress(r, l); e == nil {
+			m.DestinationAddress = &a
+		}
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	case 0x010B:
+		// Data (Optional)
+		m.Data, e = readData(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+COREF is Connection Refused message. (Message type = 0x03)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0106          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         * SCCP Cause                          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0103          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      Destination Address                      /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |  Importance   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010B          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                             Data                              /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type COREF struct {
+	RoutingContext       []uint32
+	DestinationReference uint32
+	Cause                uint32
+	DestinationAddress   *SCCPAddress
+	Importance           *uint8
+	Data                 []byte
+
+	Unknown Parameters
+}
+
+func (m *COREF) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// SCCP Cause
+	writeUint32(buf, 0x0106, m.Cause)
+
+	// Destination Address (Optional)
+	if m.DestinationAddress != nil {
+		if e := m.DestinationAddress.marshal(buf, 0x0103); e != nil {
+			return 0, 0, nil, e
+		}
+	}
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Data (Optional)
+	if len(m.Data) != 0 {
+		writeData(buf, m.Data)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x03, buf.Bytes(), nil
+}
+
+func (m *COREF) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0106:
+		// SCCP Cause
+		m.Cause, e = readUint32(r, l)
+	case 0x0103:
+		// Destination Address (Optional)
+		var a SCCPAddress
+		if a, e = readAddress(r, l); e == nil {
+			m.DestinationAddress = &a
+		}
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	case 0x010B:
+		// Data (Optional)
+		m.Data, e = readData(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+RELRE is Release Request message. (Message type = 0x04)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0104          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   * Source Reference Number                   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0106          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         * SCCP Cause                          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |  Importance   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010B          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                             Data                              /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type RELRE struct {
+	RoutingContext       []uint32
+	DestinationReference uint32
+	SourceReference      uint32
+	Cause                uint32
+	Importance           *uint8
+	Data                 []byte
+
+	Unknown Parameters
+}
+
+func (m *RELRE) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// Source Reference Number
+	writeUint32(buf, 0x0104, m.SourceReference)
+
+	// SCCP Cause
+	writeUint32(buf, 0x0106, m.Cause)
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Data (Optional)
+	if len(m.Data) != 0 {
+		writeData(buf, m.Data)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x04, buf.Bytes(), nil
+}
+
+func (m *RELRE) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0104:
+		// Source Reference Number
+		m.SourceReference, e = readUint32(r, l)
+	case 0x0106:
+		// SCCP Cause
+		m.Cause, e = readUint32(r, l)
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	case 0x010B:
+		// Data (Optional)
+		m.Data, e = readData(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+RELCO is Release Complete message. (Message type = 0x05)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0104          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   * Source Reference Number                   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |  Importance   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type RELCO struct {
+	RoutingContext       []uint32
+	DestinationReference uint32
+	SourceReference      uint32
+	Importance           *uint8
+
+	Unknown Parameters
+}
+
+func (m *RELCO) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// Source Reference Number
+	writeUint32(buf, 0x0104, m.SourceReference)
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x05, buf.Bytes(), nil
+}
+
+func (m *RELCO) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0104:
+		// Source Reference Number
+		m.SourceReference, e = readUint32(r, l)
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+RESCO is Reset Confirm message. (Message type = 0x06)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0104          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   * Source Reference Number                   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |  Importance   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type RESCO struct {
+	RoutingContext       []uint32
+	DestinationReference uint32
+	SourceReference      uint32
+	Importance           *uint8
+
+	Unknown Parameters
+}
+
+func (m *RESCO) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// Source Reference Number
+	writeUint32(buf, 0x0104, m.SourceReference)
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x06, buf.Bytes(), nil
+}
+
+func (m *RESCO) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0104:
+		// Source Reference Number
+		m.SourceReference, e = readUint32(r, l)
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+RESRE is Reset Request message. (Message type = 0x07)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0104          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   * Source Reference Number                   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0106          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         * SCCP Cause                          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0113          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |  Importance   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type RESRE struct {
+	RoutingContext       []uint32
+	DestinationReference uint32
+	SourceReference      uint32
+	Cause                uint32
+	Importance           *uint8
+
+	Unknown Parameters
+}
+
+func (m *RESRE) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// Source Reference Number
+	writeUint32(buf, 0x0104, m.SourceReference)
+
+	// SCCP Cause
+	writeUint32(buf, 0x0106, m.Cause)
+
+	// Importance (Optional)
+	if m.Importance != nil {
+		writeUint8(buf, 0x0113, *m.Importance)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x07, buf.Bytes(), nil
+}
+
+func (m *RESRE) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0104:
+		// Source Reference Number
+		m.SourceReference, e = readUint32(r, l)
+	case 0x0106:
+		// SCCP Cause
+		m.Cause, e = readUint32(r, l)
+	case 0x0113:
+		// Importance (Optional)
+		m.Importance, e = readUint8Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+CODT is Connection Oriented Data Transfer message. (Message type = 0x08)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0107          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                        Sequence Number                        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0114          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    | Msg Priority  |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0013          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                        Correlation ID                         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010B          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                            * Data                             /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type CODT struct {
+	RoutingContext       []uint32
+	SequenceNumber       *uint32
+	DestinationReference uint32
+	MessagePriority      *uint8
+	CorrelationID        *uint32
+	Data                 []byte
+
+	Unknown Parameters
+}
+
+func (m *CODT) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Sequence Number (Optional)
+	if m.SequenceNumber != nil {
+		writeUint32(buf, 0x0107, *m.SequenceNumber)
+	}
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// Message Priority (Optional)
+	if m.MessagePriority != nil {
+		writeUint8(buf, 0x0114, *m.MessagePriority)
+	}
+
+	// Correlation ID (Optional)
+	if m.CorrelationID != nil {
+		writeUint32(buf, 0x0013, *m.CorrelationID)
+	}
+
+	// Data
+	writeData(buf, m.Data)
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x08, buf.Bytes(), nil
+}
+
+func (m *CODT) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0107:
+		// Sequence Number (Optional)
+		m.SequenceNumber, e = readUint32Ptr(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0114:
+		// Message Priority (Optional)
+		m.MessagePriority, e = readUint8Ptr(r, l)
+	case 0x0013:
+		// Correlation ID (Optional)
+		m.CorrelationID, e = readUint32Ptr(r, l)
+	case 0x010B:
+		// Data
+		m.Data, e = readData(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+CODA is Connection Oriented Data Acknowledge message. (Message type = 0x09)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0108          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                    Receive Sequence Number                    |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010A          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |    Credit     |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type CODA struct {
+	RoutingContext        []uint32
+	DestinationReference  uint32
+	ReceiveSequenceNumber *uint32
+	Credit                *uint8
+
+	Unknown Parameters
+}
+
+func (m *CODA) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// Receive Sequence Number (Optional)
+	if m.ReceiveSequenceNumber != nil {
+		writeUint32(buf, 0x0108, *m.ReceiveSequenceNumber)
+	}
+
+	// Credit (Optional)
+	if m.Credit != nil {
+		writeUint8(buf, 0x010A, *m.Credit)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x09, buf.Bytes(), nil
+}
+
+func (m *CODA) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0108:
+		// Receive Sequence Number (Optional)
+		m.ReceiveSequenceNumber, e = readUint32Ptr(r, l)
+	case 0x010A:
+		// Credit (Optional)
+		m.Credit, e = readUint8Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+COERR is Connection Oriented Error message. (Message type = 0x0A)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0106          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         * SCCP Cause                          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type COERR struct {
+	RoutingContext       []uint32
+	DestinationReference uint32
+	Cause                uint32
+
+	Unknown Parameters
+}
+
+func (m *COERR) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// SCCP Cause
+	writeUint32(buf, 0x0106, m.Cause)
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x0a, buf.Bytes(), nil
+}
+
+func (m *COERR) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0106:
+		// SCCP Cause
+		m.Cause, e = readUint32(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+COIT is Inactivity Test message. (Message type = 0x0B)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0115          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |* Protocol Cl. |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0104          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   * Source Reference Number                   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0105          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                * Destination Reference Number                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0107          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                        Sequence Number                        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010A          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   Reserved                    |    Credit     |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type COIT struct {
+	RoutingContext       []uint32
+	ProtocolClass        uint8
+	SourceReference      uint32
+	DestinationReference uint32
+	SequenceNumber       *uint32
+	Credit               *uint8
+
+	Unknown Parameters
+}
+
+func (m *COIT) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Protocol Class
+	writeUint8(buf, 0x0115, m.ProtocolClass)
+
+	// Source Reference Number
+	writeUint32(buf, 0x0104, m.SourceReference)
+
+	// Destination Reference Number
+	writeUint32(buf, 0x0105, m.DestinationReference)
+
+	// Sequence Number (Optional)
+	if m.SequenceNumber != nil {
+		writeUint32(buf, 0x0107, *m.SequenceNumber)
+	}
+
+	// Credit (Optional)
+	if m.Credit != nil {
+		writeUint8(buf, 0x010A, *m.Credit)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x08, 0x0b, buf.Bytes(), nil
+}
+
+func (m *COIT) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0115:
+		// Protocol Class
+		m.ProtocolClass, e = readUint8(r, l)
+	case 0x0104:
+		// Source Reference Number
+		m.SourceReference, e = readUint32(r, l)
+	case 0x0105:
+		// Destination Reference Number
+		m.DestinationReference, e = readUint32(r, l)
+	case 0x0107:
+		// Sequence Number (Optional)
+		m.SequenceNumber, e = readUint32Ptr(r, l)
+	case 0x010A:
+		// Credit (Optional)
+		m.Credit, e = readUint8Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
@@ -0,0 +1,313 @@
+/*
+Package codec encodes and decodes SUA (RFC 3868) messages.
+
+Every message of MGMT, SNM, ASPSM, ASPTM, CL, CO and RKM class is
+available as struct with exported fields, so that SUA PDU can be built
+and parsed without running an association.
+*/
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// Version is protocol version of SUA.
+const Version uint8 = 1
+
+// HeaderLen is length of the common header.
+const HeaderLen = 8
+
+/*
+Header is common message header of SUA.
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Version    |   Reserved    | Message Class | Message Type  |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                        Message Length                         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         Message Data                          |
+*/
+type Header struct {
+	Version uint8
+	Class   uint8
+	Type    uint8
+	Length  uint32 // including the header
+}
+
+// Bytes returns binary form of the header.
+func (h Header) Bytes() []byte {
+	b := make([]byte, HeaderLen)
+	b[0] = h.Version
+	b[2] = h.Class
+	b[3] = h.Type
+	binary.BigEndian.PutUint32(b[4:], h.Length)
+	return b
+}
+
+// ParseHeader decodes common header in b.
+// Returned error is *DecodeError.
+func ParseHeader(b []byte) (h Header, e error) {
+	if len(b) < HeaderLen {
+		e = &DecodeError{Offset: len(b), Err: ErrTruncated}
+		return
+	}
+	h.Version = b[0]
+	h.Class = b[2]
+	h.Type = b[3]
+	h.Length = binary.BigEndian.Uint32(b[4:])
+	return
+}
+
+// Message is SUA message.
+type Message interface {
+	// marshal returns Message Class, Message Type and binary Message Data,
+	// or error if the message has value that can not be encoded
+	marshal() (uint8, uint8, []byte, error)
+
+	// unmarshal decodes specified Tag/length TLV value from reader
+	unmarshal(uint16, uint16, io.ReadSeeker) error
+}
+
+// Marshal returns binary form of m with common header.
+// It returns ErrInvalidGlobalTitle if SCCP address of m has invalid Global Title.
+func Marshal(m Message) ([]byte, error) {
+	c, t, b, e := m.marshal()
+	if e != nil {
+		return nil, e
+	}
+	buf := bytes.NewBuffer(Header{
+		Version: Version,
+		Class:   c,
+		Type:    t,
+		Length:  uint32(HeaderLen + len(b))}.Bytes())
+	buf.Write(b)
+	return buf.Bytes(), nil
+}
+
+// New returns empty message of the class and type.
+// Returned error is ErrorCode if the class or the type is unknown.
+func New(c, t uint8) (Message, error) {
+	switch c {
+	case 0x00:
+		switch t {
+		case 0x00:
+			return new(ERR), nil
+		case 0x01:
+			return new(NTFY), nil
+		}
+	case 0x02:
+		switch t {
+		case 0x01:
+			return new(DUNA), nil
+		case 0x02:
+			return new(DAVA), nil
+		case 0x03:
+			return new(DAUD), nil
+		case 0x04:
+			return new(SCON), nil
+		case 0x05:
+			return new(DUPU), nil
+		case 0x06:
+			return new(DRST), nil
+		}
+	case 0x03:
+		switch t {
+		case 0x01:
+			return new(ASPUP), nil
+		case 0x02:
+			return new(ASPDN), nil
+		case 0x03:
+			return new(BEAT), nil
+		case 0x04:
+			return new(ASPUPAck), nil
+		case 0x05:
+			return new(ASPDNAck), nil
+		case 0x06:
+			return new(BEATAck), nil
+		}
+	case 0x04:
+		switch t {
+		case 0x01:
+			return new(ASPAC), nil
+		case 0x02:
+			return new(ASPIA), nil
+		case 0x03:
+			return new(ASPACAck), nil
+		case 0x04:
+			return new(ASPIAAck), nil
+		}
+	case 0x07:
+		switch t {
+		case 0x01:
+			return new(CLDT), nil
+		case 0x02:
+			return new(CLDR), nil
+		}
+	case 0x08:
+		switch t {
+		case 0x01:
+			return new(CORE), nil
+		case 0x02:
+			return new(COAK), nil
+		case 0x03:
+			return new(COREF), nil
+		case 0x04:
+			return new(RELRE), nil
+		case 0x05:
+			return new(RELCO), nil
+		case 0x06:
+			return new(RESCO), nil
+		case 0x07:
+			return new(RESRE), nil
+		case 0x08:
+			return new(CODT), nil
+		case 0x09:
+			return new(CODA), nil
+		case 0x0a:
+			return new(COERR), nil
+		case 0x0b:
+			return new(COIT), nil
+		}
+	case 0x09:
+		switch t {
+		case 0x01:
+			return new(REGREQ), nil
+		case 0x02:
+			return new(REGRSP), nil
+		case 0x03:
+			return new(DEREGREQ), nil
+		case 0x04:
+			return new(DEREGRSP), nil
+		}
+	default:
+		return nil, ErrUnsupportedMessageClass
+	}
+	return nil, ErrUnsupportedMessageType
+}
+
+// mandatory parameter tags of message, keyed by class and type.
+// Other messages have no mandatory parameter.
+var mandatory = map[uint16][]uint16{
+	0x0000: {0x000C},                                         // ERR
+	0x0001: {0x000D},                                         // NTFY
+	0x0201: {0x0012},                                         // DUNA
+	0x0202: {0x0012},                                         // DAVA
+	0x0203: {0x0012},                                         // DAUD
+	0x0204: {0x0012},                                         // SCON
+	0x0205: {0x0012, 0x010C},                                 // DUPU
+	0x0206: {0x0012},                                         // DRST
+	0x0701: {0x0006, 0x0115, 0x0102, 0x0103, 0x0116, 0x010B}, // CLDT
+	0x0702: {0x0006, 0x0106, 0x0102, 0x0103},                 // CLDR
+	0x0801: {0x0006, 0x0115, 0x0104, 0x0103, 0x0116},         // CORE
+	0x0802: {0x0006, 0x0115, 0x0105, 0x0104, 0x0116},         // COAK
+	0x0803: {0x0006, 0x0105, 0x0106},                         // COREF
+	0x0804: {0x0006, 0x0105, 0x0104, 0x0106},                 // RELRE
+	0x0805: {0x0006, 0x0105, 0x0104},                         // RELCO
+	0x0806: {0x0006, 0x0105, 0x0104},                         // RESCO
+	0x0807: {0x0006, 0x0105, 0x0104, 0x0106},                 // RESRE
+	0x0808: {0x0006, 0x0105, 0x010B},                         // CODT
+	0x0809: {0x0006, 0x0105},                                 // CODA
+	0x080a: {0x0006, 0x0105, 0x0106},                         // COERR
+	0x080b: {0x0006, 0x0115, 0x0104, 0x0105},                 // COIT
+	0x0901: {0x010E},                                         // REG REQ
+	0x0902: {0x0014},                                         // REG RSP
+	0x0903: {0x0006},                                         // DEREG REQ
+	0x0904: {0x0015},                                         // DEREG RSP
+}
+
+// Unmarshal decodes SUA message in b.
+// Returned error is *DecodeError.
+func Unmarshal(b []byte) (Message, error) {
+	h, e := ParseHeader(b)
+	if e != nil {
+		return nil, e
+	}
+	fail := func(t uint16, o int, e error) (Message, error) {
+		return nil, &DecodeError{
+			Class: h.Class, Type: h.Type, Tag: t, Offset: o, Err: e}
+	}
+
+	if h.Version != Version {
+		return fail(0, 0, ErrInvalidVersion)
+	}
+	if h.Length < HeaderLen {
+		return fail(0, 4, ErrBadLength)
+	}
+	if h.Length > uint32(len(b)) {
+		return fail(0, 4, ErrTruncated)
+	}
+
+	m, e := New(h.Class, h.Type)
+	if e != nil {
+		return fail(0, 2, e)
+	}
+
+	seen := make(map[uint16]bool)
+	if t, o, e := decodeParams(b[HeaderLen:h.Length], func(t, l uint16, r io.ReadSeeker) error {
+		seen[t] = true
+		return m.unmarshal(t, l, r)
+	}); e != nil {
+		return fail(t, HeaderLen+o, e)
+	}
+
+	for _, t := range mandatory[uint16(h.Class)<<8|uint16(h.Type)] {
+		if !seen[t] {
+			return fail(t, HeaderLen, ErrMissingParameter)
+		}
+	}
+	return m, nil
+}
+
+// decodeParams decodes TLV parameters in body by f.
+// On error, it returns tag and offset of the invalid field.
+func decodeParams(body []byte, f func(uint16, uint16, io.ReadSeeker) error) (uint16, int, error) {
+	r := bytes.NewReader(body)
+	for r.Len() != 0 {
+		o := len(body) - r.Len()
+		if r.Len() < 4 {
+			return 0, o, ErrTruncated
+		}
+		var t, l uint16
+		binary.Read(r, binary.BigEndian, &t)
+		binary.Read(r, binary.BigEndian, &l)
+		if l < 4 {
+			return t, o + 2, ErrBadLength
+		}
+		if int(l-4) > r.Len() {
+			return t, o + 2, ErrTruncated
+		}
+
+		// value and padding of the parameter
+		end := o + int(l)
+		next := end
+		if l%4 != 0 {
+			next += int(4 - l%4)
+		}
+		if next > len(body) {
+			// last padding is omitted
+			next = len(body)
+		}
+		for i := end; i < next; i++ {
+			if body[i] != 0 {
+				return t, i, ErrBadPadding
+			}
+		}
+
+		if e := f(t, l-4, r); e != nil {
+			if e == io.EOF || e == io.ErrUnexpectedEOF {
+				e = ErrTruncated
+			}
+			return t, o + 4, e
+		}
+		if pos := len(body) - r.Len(); pos > end {
+			// parameter decoder overrun
+			return t, o + 4, ErrBadLength
+		}
+		r.Seek(int64(next), io.SeekStart)
+	}
+	return 0, 0, nil
+}
@@ -0,0 +1,82 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+)
+
+// msg returns message of class c and type t with body b.
+func msg(c, t uint8, b ...byte) []byte {
+	return append(Header{
+		Version: Version, Class: c, Type: t,
+		Length: uint32(HeaderLen + len(b))}.Bytes(), b...)
+}
+
+func TestUnmarshalError(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		buf    []byte
+		err    error
+		tag    uint16
+		offset int
+	}{
+		{"short header", []byte{1, 0, 3, 1}, ErrTruncated, 0, 4},
+		{"invalid version", []byte{2, 0, 3, 1, 0, 0, 0, 8}, ErrInvalidVersion, 0, 0},
+		{"short length", []byte{1, 0, 3, 1, 0, 0, 0, 4}, ErrBadLength, 0, 4},
+		{"long length", []byte{1, 0, 3, 1, 0, 0, 0, 12}, ErrTruncated, 0, 4},
+		{"unknown class", msg(10, 1), ErrUnsupportedMessageClass, 0, 2},
+		{"unknown type", msg(3, 9), ErrUnsupportedMessageType, 0, 2},
+
+		// ASPUP with ASP Identifier
+		{"truncated tag", msg(3, 1, 0x00, 0x11),
+			ErrTruncated, 0, 8},
+		{"bad parameter length", msg(3, 1, 0x00, 0x11, 0x00, 0x02),
+			ErrBadLength, 0x0011, 10},
+		{"truncated parameter", msg(3, 1, 0x00, 0x11, 0x00, 0x08, 0x00, 0x00),
+			ErrTruncated, 0x0011, 10},
+		{"bad value length", msg(3, 1, 0x00, 0x11, 0x00, 0x06, 0x00, 0x00),
+			ErrBadLength, 0x0011, 12},
+
+		// ASPUP with Info String
+		{"missing padding", msg(3, 1,
+			0x00, 0x04, 0x00, 0x05, 'a',
+			0x00, 0x11, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01),
+			ErrBadPadding, 0x0004, 14},
+		{"non zero padding", msg(3, 1,
+			0x00, 0x04, 0x00, 0x07, 'a', 'b', 'c', 0x01),
+			ErrBadPadding, 0x0004, 15},
+
+		// mandatory parameters
+		{"ERR without Error Code", msg(0, 0), ErrMissingParameter, 0x000C, 8},
+		{"CLDT without Routing Context", msg(7, 1), ErrMissingParameter, 0x0006, 8},
+		{"DUPU without Cause", msg(2, 5,
+			0x00, 0x12, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01),
+			ErrMissingParameter, 0x010C, 8},
+	} {
+		m, e := Unmarshal(tc.buf)
+		var de *DecodeError
+		if !errors.As(e, &de) {
+			t.Errorf("%s: %#v, %v", tc.name, m, e)
+			continue
+		}
+		if !errors.Is(e, tc.err) || de.Tag != tc.tag || de.Offset != tc.offset {
+			t.Errorf("%s: err=%v tag=0x%04x offset=%d, want %v tag=0x%04x offset=%d",
+				tc.name, de.Err, de.Tag, de.Offset, tc.err, tc.tag, tc.offset)
+		}
+	}
+}
+
+func TestUnmarshalPadding(t *testing.T) {
+	// padding of the last parameter can be omitted
+	for _, b := range [][]byte{
+		msg(3, 1, 0x00, 0x04, 0x00, 0x07, 'a', 'b', 'c'),
+		msg(3, 1, 0x00, 0x04, 0x00, 0x07, 'a', 'b', 'c', 0x00),
+	} {
+		m, e := Unmarshal(b)
+		if e != nil {
+			t.Errorf("% x: %v", b, e)
+		} else if up, ok := m.(*ASPUP); !ok || up.InfoString != "abc" {
+			t.Errorf("% x: %#v", b, m)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode is Error Code parameter of ERR message.
+type ErrorCode uint32
+
+// Error Code values defined in RFC 3868.
+const (
+	ErrInvalidVersion                 ErrorCode = 0x01
+	ErrUnsupportedMessageClass        ErrorCode = 0x03
+	ErrUnsupportedMessageType         ErrorCode = 0x04
+	ErrUnsupportedTrafficHandlingMode ErrorCode = 0x05
+	ErrUnexpectedMessage              ErrorCode = 0x06
+	ErrProtocolError                  ErrorCode = 0x07
+	ErrInvalidStreamIdentifier        ErrorCode = 0x09
+	ErrRefusedManagementBlocking      ErrorCode = 0x0d
+	ErrASPIdentifierRequired          ErrorCode = 0x0e
+	ErrInvalidASPIdentifier           ErrorCode = 0x0f
+	ErrInvalidParameterValue          ErrorCode = 0x11
+	ErrParameterFieldError            ErrorCode = 0x12
+	ErrUnexpectedParameter            ErrorCode = 0x13
+	ErrDestinationStatusUnknown       ErrorCode = 0x14
+	ErrInvalidNetworkAppearance       ErrorCode = 0x15
+	ErrMissingParameter               ErrorCode = 0x16
+	ErrInvalidRoutingContext          ErrorCode = 0x19
+	ErrNoConfiguredASForASP           ErrorCode = 0x1a
+	ErrSubsystemStatusUnknown         ErrorCode = 0x1b
+	ErrInvalidLoadsharingLabel        ErrorCode = 0x1c
+)
+
+func (c ErrorCode) Error() string {
+	switch c {
+	case ErrInvalidVersion:
+		return "invalid version"
+	case ErrUnsupportedMessageClass:
+		return "unsupported message class"
+	case ErrUnsupportedMessageType:
+		return "unsupported message type"
+	case ErrUnsupportedTrafficHandlingMode:
+		return "unsupported traffic handling mode"
+	case ErrUnexpectedMessage:
+		return "unexpected message"
+	case ErrProtocolError:
+		return "protocol error"
+	case ErrInvalidStreamIdentifier:
+		return "invalid stream identifier"
+	case ErrRefusedManagementBlocking:
+		return "refused - management blocking"
+	case ErrASPIdentifierRequired:
+		return "ASP identifier required"
+	case ErrInvalidASPIdentifier:
+		return "invalid ASP identifier"
+	case ErrInvalidParameterValue:
+		return "invalid parameter value"
+	case ErrParameterFieldError:
+		return "parameter field error"
+	case ErrUnexpectedParameter:
+		return "unexpected parameter"
+	case ErrDestinationStatusUnknown:
+		return "destination status unknown"
+	case ErrInvalidNetworkAppearance:
+		return "invalid network appearance"
+	case ErrMissingParameter:
+		return "missing parameter"
+	case ErrInvalidRoutingContext:
+		return "invalid routing context"
+	case ErrNoConfiguredASForASP:
+		return "no configured AS for ASP"
+	case ErrSubsystemStatusUnknown:
+		return "subsystem status unknown"
+	case ErrInvalidLoadsharingLabel:
+		return "invalid loadsharing label"
+	}
+	return fmt.Sprintf("error code 0x%02x", uint32(c))
+}
+
+// Errors on decoding received message.
+var (
+	ErrTruncated  = errors.New("truncated message")
+	ErrBadLength  = errors.New("invalid length")
+	ErrBadPadding = errors.New("invalid padding")
+)
+
+// DecodeError is error on decoding received message.
+// Err is ErrTruncated, ErrBadLength, ErrBadPadding or ErrorCode.
+type DecodeError struct {
+	Class  uint8
+	Type   uint8
+	Tag    uint16 // parameter tag, or zero for message header
+	Offset int    // offset of the invalid field in the message
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Tag == 0 {
+		return fmt.Sprintf("decode message(class=%d, type=%d) at %d: %s",
+			e.Class, e.Type, e.Offset, e.Err)
+	}
+	return fmt.Sprintf("decode message(class=%d, type=%d) parameter 0x%04x at %d: %s",
+		e.Class, e.Type, e.Tag, e.Offset, e.Err)
+}
+
+// Unwrap returns Err of the error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns Error Code of ERR message for the error.
+func (e *DecodeError) Code() ErrorCode {
+	if c, ok := e.Err.(ErrorCode); ok {
+		return c
+	}
+	return ErrParameterFieldError
+}
@@ -0,0 +1,145 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// seedCorpus returns messages in testdata/corpus.txt.
+// Lines starting with # are comments.
+func seedCorpus(f *testing.F) (ret [][]byte) {
+	b, e := os.ReadFile(filepath.Join("testdata", "corpus.txt"))
+	if e != nil {
+		f.Fatal(e)
+	}
+	for _, l := range strings.Split(string(b), "\n") {
+		if l = strings.TrimSpace(l); l == "" || l[0] == '#' {
+			continue
+		}
+		m, e := hex.DecodeString(l)
+		if e != nil {
+			f.Fatal(e)
+		}
+		ret = append(ret, m)
+	}
+	return
+}
+
+// FuzzDecode checks that any message is decoded without panic,
+// and decoded message is encoded and decoded again.
+func FuzzDecode(f *testing.F) {
+	for _, b := range seedCorpus(f) {
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		m, e := Unmarshal(b)
+		if e != nil {
+			if _, ok := e.(*DecodeError); !ok {
+				t.Fatalf("untyped decode error %v", e)
+			}
+			return
+		}
+		// decoded message can always be encoded
+		if b, e = Marshal(m); e != nil {
+			t.Fatalf("encode %+v: %v", m, e)
+		}
+		if m, e = Unmarshal(b); e != nil {
+			t.Fatalf("re-decode %+v: %v", m, e)
+		}
+		if b2, _ := Marshal(m); !bytes.Equal(b, b2) {
+			// unknown parameters must be kept
+			t.Fatalf("re-encode %+v: % x", m, b)
+		}
+	})
+}
+
+// FuzzUnmarshal checks parameter decoder of every received message.
+func FuzzUnmarshal(f *testing.F) {
+	for _, b := range seedCorpus(f) {
+		for r := bytes.NewReader(b[8:]); r.Len() >= 4; {
+			var t, l uint16
+			binary.Read(r, binary.BigEndian, &t)
+			binary.Read(r, binary.BigEndian, &l)
+			v := make([]byte, l-4)
+			r.Read(v)
+			r.Seek(int64((4-l%4)%4), 1)
+			f.Add(b[2], b[3], t, v)
+		}
+	}
+	f.Fuzz(func(t *testing.T, c, typ uint8, tag uint16, v []byte) {
+		if len(v) > 0xfffb {
+			return
+		}
+		m, e := New(c, typ)
+		if e != nil {
+			return
+		}
+		m.unmarshal(tag, uint16(len(v)), bytes.NewReader(v))
+	})
+}
+
+// FuzzReadAddress checks SCCP address decoder.
+func FuzzReadAddress(f *testing.F) {
+	for _, a := range []SCCPAddress{
+		{
+			NatureOfAddress: NAI_International,
+			NumberingPlan:   NPI_E164,
+			GlobalTitle:     "12345",
+			SubsystemNumber: 0x06},
+		{
+			TranslationType: 0x01,
+			GlobalTitle:     "819012345678",
+			PointCode:       PointCode{Value: 1234},
+			SubsystemNumber: 0x07},
+		{PointCode: PointCode{Value: 1234}, SubsystemNumber: 0x08},
+		{
+			RoutingIndicator:     RI_GT,
+			AddressIndicator:     AI_GT | AI_SSN,
+			GlobalTitleIndicator: GTI_NAI,
+			NatureOfAddress:      NAI_International,
+			GlobalTitle:          "81901BCDEF",
+			SubsystemNumber:      0x92},
+		{Hostname: "sg1.example.net", SubsystemNumber: 0x06},
+		{
+			IPAddress:       netip.MustParseAddr("192.0.2.1"),
+			SubsystemNumber: 0x06},
+		{
+			IPAddress:       netip.MustParseAddr("2001:db8::1"),
+			SubsystemNumber: 0x06},
+		{
+			SubsystemNumber: 0x06,
+			Unknown:         Parameters{{Tag: 0x8010, Value: []byte{1, 2, 3}}}},
+	} {
+		buf := new(bytes.Buffer)
+		a.marshal(buf, 0x0102)
+		f.Add(buf.Bytes()[4:])
+	}
+	f.Fuzz(func(t *testing.T, v []byte) {
+		if len(v) > 0xfffb {
+			return
+		}
+		a, e := readAddress(bytes.NewReader(v), uint16(len(v)))
+		if e != nil {
+			return
+		}
+
+		// decoded address must be encoded and decoded again
+		buf := new(bytes.Buffer)
+		a.marshal(buf, 0x0102)
+		b := buf.Bytes()[4:]
+		if a, e = readAddress(bytes.NewReader(b), uint16(len(b))); e != nil {
+			t.Fatalf("re-decode %+v: %v", a, e)
+		}
+		buf.Reset()
+		a.marshal(buf, 0x0102)
+		if !bytes.Equal(b, buf.Bytes()[4:]) {
+			t.Fatalf("re-encode %+v: % x", a, b)
+		}
+	})
+}
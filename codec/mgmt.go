@@ -0,0 +1,200 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+/*
+MGMT: UA Management Messages
+Message class = 0x00
+*/
+
+/*
+ERR is Error message. (Message type = 0x00)
+Direction is SGP -> ASP.
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x000C         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                        * Error Code                           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0012          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC 1                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                        Affected Point Code                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |          Length = 8           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                     Network Appearance                        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0007         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                        Diagnostic Info                        /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type ERR struct {
+	Code              ErrorCode
+	RoutingContext    []uint32
+	AffectedPointCode []PointCode
+	NetworkAppearance *uint32
+	DiagnosticInfo    []byte
+
+	Unknown Parameters
+}
+
+func (m *ERR) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Error Code
+	writeUint32(buf, 0x000C, uint32(m.Code))
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Affected Point Code (Optional)
+	if len(m.AffectedPointCode) != 0 {
+		writeAPC(buf, m.AffectedPointCode)
+	}
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// Diagnostic Info (Optional)
+	if len(m.DiagnosticInfo) != 0 {
+		writeDiagnostic(buf, m.DiagnosticInfo)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x00, 0x00, buf.Bytes(), nil
+}
+
+func (m *ERR) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x000C:
+		// Error Code
+		var c uint32
+		c, e = readUint32(r, l)
+		m.Code = ErrorCode(c)
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0012:
+		// Affected Point Code (Optional)
+		m.AffectedPointCode, e = readAPC(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	case 0x0007:
+		// Diagnostic Info (Optional)
+		m.DiagnosticInfo, e = readData(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+NTFY is Notify message. (Message type = 0x01)
+
+	 0                     1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x000D         |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         * Status                              |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|            Tag = 0x0011       |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                        ASP Identifier                         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-
+	|          Tag = 0x0006         |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0004         |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type NTFY struct {
+	StatusType     uint16
+	StatusInfo     uint16
+	ASPIdentifier  *uint32
+	RoutingContext []uint32
+	InfoString     string
+
+	Unknown Parameters
+}
+
+func (m *NTFY) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Status
+	writeUint32(buf, 0x000D, uint32(m.StatusType)<<16|uint32(m.StatusInfo))
+
+	// ASP Identifier (Optional)
+	if m.ASPIdentifier != nil {
+		writeUint32(buf, 0x0011, *m.ASPIdentifier)
+	}
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x00, 0x01, buf.Bytes(), nil
+}
+
+func (m *NTFY) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x000D:
+		// Status
+		if l != 4 {
+			e = ErrBadLength
+		} else if e = binary.Read(r, binary.BigEndian, &m.StatusType); e == nil {
+			e = binary.Read(r, binary.BigEndian, &m.StatusInfo)
+		}
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0011:
+		// ASP Identifier (Optional)
+		m.ASPIdentifier, e = readUint32Ptr(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+// 0x02 TEI Status Request
+// 0x03 TEI Status Confirm
+// 0x04 TEI Status Indication
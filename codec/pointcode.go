@@ -0,0 +1,167 @@
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PointCodeVariant is variant of SS7 point code.
+type PointCodeVariant uint8
+
+const (
+	PC_ITU   PointCodeVariant = 0 // ITU-T 14-bit, 3-8-3
+	PC_ANSI  PointCodeVariant = 1 // ANSI 24-bit, 8-8-8
+	PC_Japan PointCodeVariant = 2 // Japanese 16-bit, 5-4-7
+)
+
+// DefaultPointCodeVariant is variant of decoded point code.
+var DefaultPointCodeVariant = PC_ITU
+
+// ErrInvalidPointCode is error on parsing point code.
+var ErrInvalidPointCode = errors.New("invalid point code")
+
+// fields returns bit length of each field in text notation.
+func (v PointCodeVariant) fields() []uint {
+	switch v {
+	case PC_ANSI:
+		return []uint{8, 8, 8}
+	case PC_Japan:
+		return []uint{5, 4, 7}
+	}
+	return []uint{3, 8, 3}
+}
+
+// Bits returns bit length of point code.
+func (v PointCodeVariant) Bits() (l uint) {
+	for _, f := range v.fields() {
+		l += f
+	}
+	return
+}
+
+/*
+PointCode is SS7 point code.
+Mask is number of wildcarded least significant bits in Affected Point Code.
+
+Affected Point Code
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0012          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC 1                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                              ...                              /
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC n                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type PointCode struct {
+	Variant PointCodeVariant
+	Mask    uint8
+	Value   uint32
+}
+
+// ParsePointCode parses s in 3-8-3, 8-8-8 or 5-4-7 notation of v,
+// or in decimal. Mask is given as "/" and number of bits.
+func ParsePointCode(s string, v PointCodeVariant) (p PointCode, e error) {
+	p.Variant = v
+	if i := strings.LastIndexByte(s, '/'); i >= 0 {
+		m, err := strconv.ParseUint(s[i+1:], 10, 8)
+		if err != nil || m > uint64(v.Bits()) {
+			return PointCode{}, ErrInvalidPointCode
+		}
+		p.Mask = uint8(m)
+		s = s[:i]
+	}
+
+	f := strings.Split(s, "-")
+	if len(f) == 1 {
+		n, err := strconv.ParseUint(s, 10, int(v.Bits()))
+		if err != nil {
+			return PointCode{}, ErrInvalidPointCode
+		}
+		p.Value = uint32(n)
+		return
+	}
+
+	w := v.fields()
+	if len(f) != len(w) {
+		return PointCode{}, ErrInvalidPointCode
+	}
+	for i, s := range f {
+		n, err := strconv.ParseUint(s, 10, int(w[i]))
+		if err != nil {
+			return PointCode{}, ErrInvalidPointCode
+		}
+		p.Value = p.Value<<w[i] | uint32(n)
+	}
+	return
+}
+
+// String returns p in notation of the variant.
+// Decimal is returned if p is not valid for the variant.
+func (p PointCode) String() string {
+	w := p.Variant.fields()
+	f := make([]string, len(w))
+	v := p.Value
+	for i := len(w) - 1; i >= 0; i-- {
+		f[i] = strconv.FormatUint(uint64(v&(1<<w[i]-1)), 10)
+		v >>= w[i]
+	}
+	s := strings.Join(f, "-")
+	if v != 0 {
+		s = p.Decimal()
+	}
+	if p.Mask != 0 {
+		s += "/" + strconv.Itoa(int(p.Mask))
+	}
+	return s
+}
+
+// Decimal returns p in decimal notation without mask.
+func (p PointCode) Decimal() string {
+	return strconv.FormatUint(uint64(p.Value), 10)
+}
+
+// Valid returns true if p and its mask are in range of the variant.
+func (p PointCode) Valid() bool {
+	l := p.Variant.Bits()
+	return p.Value>>l == 0 && uint(p.Mask) <= l
+}
+
+// Contains returns true if q is included in p with mask.
+func (p PointCode) Contains(q PointCode) bool {
+	return p.Value>>p.Mask == q.Value>>p.Mask
+}
+
+func writeAPC(w io.Writer, v []PointCode) {
+	binary.Write(w, binary.BigEndian, uint16(0x0012))
+	binary.Write(w, binary.BigEndian, uint16(4+4*len(v)))
+	for _, a := range v {
+		w.Write([]byte{
+			a.Mask, byte(a.Value >> 16), byte(a.Value >> 8), byte(a.Value)})
+	}
+}
+
+func readAPC(r io.ReadSeeker, l uint16) (v []PointCode, e error) {
+	if l%4 != 0 {
+		e = ErrBadLength
+	} else {
+		v = make([]PointCode, l/4)
+		for i := range v {
+			var pc uint32
+			if pc, e = readUint32(r, 4); e != nil {
+				break
+			}
+			v[i].Variant = DefaultPointCodeVariant
+			v[i].Mask = byte(pc >> 24)
+			v[i].Value = pc & 0x00ffffff
+		}
+	}
+	return
+}
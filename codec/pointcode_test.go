@@ -0,0 +1,115 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePointCode(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		v   PointCodeVariant
+		pc  PointCode
+		str string
+	}{
+		// ITU 3-8-3
+		{"2-100-5", PC_ITU, PointCode{PC_ITU, 0, 2<<11 | 100<<3 | 5}, "2-100-5"},
+		{"7-255-7", PC_ITU, PointCode{PC_ITU, 0, 0x3fff}, "7-255-7"},
+		{"0-0-0", PC_ITU, PointCode{PC_ITU, 0, 0}, "0-0-0"},
+		{"4901", PC_ITU, PointCode{PC_ITU, 0, 4901}, "2-100-5"},
+		{"2-100-5/3", PC_ITU, PointCode{PC_ITU, 3, 4901}, "2-100-5/3"},
+		{"4901/14", PC_ITU, PointCode{PC_ITU, 14, 4901}, "2-100-5/14"},
+
+		// ANSI 8-8-8
+		{"1-2-3", PC_ANSI, PointCode{PC_ANSI, 0, 1<<16 | 2<<8 | 3}, "1-2-3"},
+		{"255-255-255", PC_ANSI, PointCode{PC_ANSI, 0, 0xffffff}, "255-255-255"},
+		{"66051", PC_ANSI, PointCode{PC_ANSI, 0, 66051}, "1-2-3"},
+		{"1-2-0/8", PC_ANSI, PointCode{PC_ANSI, 8, 1<<16 | 2<<8}, "1-2-0/8"},
+
+		// Japan 5-4-7
+		{"1-2-3", PC_Japan, PointCode{PC_Japan, 0, 1<<11 | 2<<7 | 3}, "1-2-3"},
+		{"31-15-127", PC_Japan, PointCode{PC_Japan, 0, 0xffff}, "31-15-127"},
+		{"2307", PC_Japan, PointCode{PC_Japan, 0, 2307}, "1-2-3"},
+		{"1-2-0/7", PC_Japan, PointCode{PC_Japan, 7, 1<<11 | 2<<7}, "1-2-0/7"},
+	} {
+		pc, e := ParsePointCode(tc.in, tc.v)
+		if e != nil {
+			t.Errorf("%q (%d): %v", tc.in, tc.v, e)
+			continue
+		}
+		if pc != tc.pc {
+			t.Errorf("%q (%d): %+v, want %+v", tc.in, tc.v, pc, tc.pc)
+		}
+		if !pc.Valid() {
+			t.Errorf("%q (%d): not valid", tc.in, tc.v)
+		}
+		if s := pc.String(); s != tc.str {
+			t.Errorf("%q (%d): String()=%q, want %q", tc.in, tc.v, s, tc.str)
+		}
+		if rt, e := ParsePointCode(pc.String(), tc.v); e != nil || rt != pc {
+			t.Errorf("%q (%d): round trip %+v, %v", tc.in, tc.v, rt, e)
+		}
+	}
+}
+
+func TestParsePointCodeInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		in string
+		v  PointCodeVariant
+	}{
+		{"8-0-0", PC_ITU},
+		{"0-256-0", PC_ITU},
+		{"0-0-8", PC_ITU},
+		{"16384", PC_ITU},
+		{"1-2", PC_ITU},
+		{"1-2-3-4", PC_ITU},
+		{"1-x-3", PC_ITU},
+		{"", PC_ITU},
+		{"-1", PC_ITU},
+		{"1-2-3/15", PC_ITU},
+		{"1-2-3/x", PC_ITU},
+		{"256-0-0", PC_ANSI},
+		{"16777216", PC_ANSI},
+		{"1-2-3/25", PC_ANSI},
+		{"32-0-0", PC_Japan},
+		{"0-16-0", PC_Japan},
+		{"0-0-128", PC_Japan},
+		{"65536", PC_Japan},
+	} {
+		if pc, e := ParsePointCode(tc.in, tc.v); !errors.Is(e, ErrInvalidPointCode) {
+			t.Errorf("%q (%d): %+v, %v", tc.in, tc.v, pc, e)
+		}
+	}
+}
+
+func TestPointCodeString(t *testing.T) {
+	for _, tc := range []struct {
+		pc  PointCode
+		str string
+	}{
+		// out of range value is shown in decimal
+		{PointCode{PC_ITU, 0, 0x4000}, "16384"},
+		{PointCode{PC_Japan, 0, 0x10000}, "65536"},
+		{PointCode{PC_ITU, 2, 0x4000}, "16384/2"},
+		{PointCode{PC_ANSI, 0, 0x1000000}, "16777216"},
+	} {
+		if s := tc.pc.String(); s != tc.str {
+			t.Errorf("%+v: String()=%q, want %q", tc.pc, s, tc.str)
+		}
+		if tc.pc.Valid() {
+			t.Errorf("%+v: valid", tc.pc)
+		}
+	}
+}
+
+func TestPointCodeContains(t *testing.T) {
+	p, _ := ParsePointCode("2-100-0/3", PC_ITU)
+	for _, s := range []string{"2-100-0", "2-100-7"} {
+		if q, _ := ParsePointCode(s, PC_ITU); !p.Contains(q) {
+			t.Errorf("%s is not contained in %s", q, p)
+		}
+	}
+	if q, _ := ParsePointCode("2-101-0", PC_ITU); p.Contains(q) {
+		t.Errorf("%s is contained in %s", q, p)
+	}
+}
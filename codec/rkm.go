@@ -0,0 +1,413 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+)
+
+/*
+RKM: Routing Key Management Messages
+Message class = 0x09
+*/
+
+/*
+REGREQ is Registration Request message. (Message type = 0x01)
+Direction is ASP -> SGP.
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x010E         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                        * Routing Key 1                        /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                              ...                              /
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x010E         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Routing Key n                        /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type REGREQ struct {
+	RoutingKey []RoutingKey
+
+	Unknown Parameters
+}
+
+func (m *REGREQ) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Key
+	for i := range m.RoutingKey {
+		if e := m.RoutingKey[i].marshal(buf); e != nil {
+			return 0, 0, nil, e
+		}
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x09, 0x01, buf.Bytes(), nil
+}
+
+func (m *REGREQ) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x010E:
+		// Routing Key
+		var k RoutingKey
+		if k, e = readRoutingKey(r, l); e == nil {
+			m.RoutingKey = append(m.RoutingKey, k)
+		}
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+RoutingKey is Routing Key parameter.
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0018         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                    * Local-RK-Identifier                      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x000B         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                       Traffic Mode Type                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x010D         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0103         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      Destination Address                      /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0102         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                        Source Address                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type RoutingKey struct {
+	LocalRKID          uint32
+	TrafficMode        uint32
+	NetworkAppearance  *uint32
+	DestinationAddress []SCCPAddress
+	SourceAddress      []SCCPAddress
+
+	Unknown Parameters
+}
+
+func (k *RoutingKey) marshal(w io.Writer) error {
+	buf := new(bytes.Buffer)
+
+	// Local-RK-Identifier
+	writeUint32(buf, 0x0018, k.LocalRKID)
+
+	// Traffic Mode Type (Optional)
+	if k.TrafficMode != 0 {
+		writeUint32(buf, 0x000B, k.TrafficMode)
+	}
+
+	// Network Appearance (Optional)
+	if k.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *k.NetworkAppearance)
+	}
+
+	// Destination Address (Optional)
+	for i := range k.DestinationAddress {
+		if e := k.DestinationAddress[i].marshal(buf, 0x0103); e != nil {
+			return e
+		}
+	}
+
+	// Source Address (Optional)
+	for i := range k.SourceAddress {
+		if e := k.SourceAddress[i].marshal(buf, 0x0102); e != nil {
+			return e
+		}
+	}
+
+	// Unknown parameters
+	k.Unknown.marshal(buf)
+	writeBytes(w, 0x010E, buf.Bytes())
+	return nil
+}
+
+func readRoutingKey(r io.ReadSeeker, l uint16) (k RoutingKey, e error) {
+	var b []byte
+	if b, e = readData(r, l); e != nil {
+		return
+	}
+	_, _, e = decodeParams(b, func(t, l uint16, r io.ReadSeeker) (e error) {
+		switch t {
+		case 0x0018:
+			// Local-RK-Identifier
+			k.LocalRKID, e = readUint32(r, l)
+		case 0x000B:
+			// Traffic Mode Type (Optional)
+			k.TrafficMode, e = readUint32(r, l)
+		case 0x010D:
+			// Network Appearance (Optional)
+			k.NetworkAppearance, e = readUint32Ptr(r, l)
+		case 0x0103:
+			// Destination Address (Optional)
+			var a SCCPAddress
+			if a, e = readAddress(r, l); e == nil {
+				k.DestinationAddress = append(k.DestinationAddress, a)
+			}
+		case 0x0102:
+			// Source Address (Optional)
+			var a SCCPAddress
+			if a, e = readAddress(r, l); e == nil {
+				k.SourceAddress = append(k.SourceAddress, a)
+			}
+		default:
+			e = k.Unknown.unmarshal(t, l, r)
+		}
+		return
+	})
+	return
+}
+
+/*
+REGRSP is Registration Response message. (Message type = 0x02)
+Direction is SGP -> ASP.
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0014         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                   * Registration Result 1                     /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                              ...                              /
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0014         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                     Registration Result n                     /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+Registration Result
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0018         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                    * Local-RK-Identifier                      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0016         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                    * Registration Status                      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0006         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      * Routing Context                        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type REGRSP struct {
+	Result []RegistrationResult
+
+	Unknown Parameters
+}
+
+// RegistrationResult is Registration Result parameter.
+type RegistrationResult struct {
+	LocalRKID      uint32
+	Status         uint32
+	RoutingContext uint32
+}
+
+func (m *REGRSP) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Registration Result
+	for _, v := range m.Result {
+		res := new(bytes.Buffer)
+		writeUint32(res, 0x0018, v.LocalRKID)
+		writeUint32(res, 0x0016, v.Status)
+		writeUint32(res, 0x0006, v.RoutingContext)
+		writeBytes(buf, 0x0014, res.Bytes())
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x09, 0x02, buf.Bytes(), nil
+}
+
+func (m *REGRSP) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0014:
+		// Registration Result
+		var b []byte
+		if b, e = readData(r, l); e != nil {
+			return
+		}
+		var v RegistrationResult
+		_, _, e = decodeParams(b, func(t, l uint16, r io.ReadSeeker) (e error) {
+			switch t {
+			case 0x0018:
+				// Local-RK-Identifier
+				v.LocalRKID, e = readUint32(r, l)
+			case 0x0016:
+				// Registration Status
+				v.Status, e = readUint32(r, l)
+			case 0x0006:
+				// Routing Context
+				v.RoutingContext, e = readUint32(r, l)
+			default:
+				_, e = r.Seek(int64(l), io.SeekCurrent)
+			}
+			return
+		})
+		if e == nil {
+			m.Result = append(m.Result, v)
+		}
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+DEREGREQ is Deregistration Request message. (Message type = 0x03)
+Direction is ASP -> SGP.
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0006         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       * Routing Context                       /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type DEREGREQ struct {
+	RoutingContext []uint32
+
+	Unknown Parameters
+}
+
+func (m *DEREGREQ) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context
+	writeRoutingContext(buf, m.RoutingContext)
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x09, 0x03, buf.Bytes(), nil
+}
+
+func (m *DEREGREQ) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context
+		m.RoutingContext, e = readRoutingContext(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+DEREGRSP is Deregistration Response message. (Message type = 0x04)
+Direction is SGP -> ASP.
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0015         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                  * Deregistration Result 1                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                              ...                              /
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0015         |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                    Deregistration Result n                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+Deregistration Result
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0006         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      * Routing Context                        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Tag = 0x0017         |           Length = 8          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                   * Deregistration Status                     |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type DEREGRSP struct {
+	Result []DeregistrationResult
+
+	Unknown Parameters
+}
+
+// DeregistrationResult is Deregistration Result parameter.
+type DeregistrationResult struct {
+	RoutingContext uint32
+	Status         uint32
+}
+
+func (m *DEREGRSP) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Deregistration Result
+	for _, v := range m.Result {
+		res := new(bytes.Buffer)
+		writeUint32(res, 0x0006, v.RoutingContext)
+		writeUint32(res, 0x0017, v.Status)
+		writeBytes(buf, 0x0015, res.Bytes())
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x09, 0x04, buf.Bytes(), nil
+}
+
+func (m *DEREGRSP) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0015:
+		// Deregistration Result
+		var b []byte
+		if b, e = readData(r, l); e != nil {
+			return
+		}
+		var v DeregistrationResult
+		_, _, e = decodeParams(b, func(t, l uint16, r io.ReadSeeker) (e error) {
+			switch t {
+			case 0x0006:
+				// Routing Context
+				v.RoutingContext, e = readUint32(r, l)
+			case 0x0017:
+				// Deregistration Status
+				v.Status, e = readUint32(r, l)
+			default:
+				_, e = r.Seek(int64(l), io.SeekCurrent)
+			}
+			return
+		})
+		if e == nil {
+			m.Result = append(m.Result, v)
+		}
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
@@ -0,0 +1,681 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+/*
+SNM: Signalling Network Management Messages
+Message class = 0x02
+*/
+
+/*
+DUNA is Destination Unavailable message. (Message type = 0x01)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0012          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC 1                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      * Affected Point Code                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8003          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                 Reserved                      |   SSN value   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0112          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                    Reserved                   |      SMI      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0004          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type DUNA struct {
+	RoutingContext    []uint32
+	NetworkAppearance *uint32
+	AffectedPointCode []PointCode
+	SubsystemNumber   uint8
+	SMI               uint8
+	InfoString        string
+
+	Unknown Parameters
+}
+
+func (m *DUNA) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// Affected Point Code
+	writeAPC(buf, m.AffectedPointCode)
+
+	// SSN (Optional)
+	if m.SubsystemNumber != 0 {
+		writeUint8(buf, 0x8003, m.SubsystemNumber)
+	}
+
+	// SMI (Optional)
+	if m.SMI != 0 {
+		writeUint8(buf, 0x0112, m.SMI)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x02, 0x01, buf.Bytes(), nil
+}
+
+func (m *DUNA) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0012:
+		// Affected Point Code
+		m.AffectedPointCode, e = readAPC(r, l)
+	case 0x8003:
+		// SSN (Optional)
+		m.SubsystemNumber, e = readUint8(r, l)
+	case 0x0112:
+		// SMI (Optional)
+		m.SMI, e = readUint8(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+DAVA is Destination Available message. (Message type = 0x02)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0012          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC 1                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      * Affected Point Code                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8003          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                 Reserved                      |   SSN value   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0112          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                    Reserved                   |      SMI      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0004          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type DAVA struct {
+	RoutingContext    []uint32
+	NetworkAppearance *uint32
+	AffectedPointCode []PointCode
+	SubsystemNumber   uint8
+	SMI               uint8
+	InfoString        string
+
+	Unknown Parameters
+}
+
+func (m *DAVA) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// Affected Point Code
+	writeAPC(buf, m.AffectedPointCode)
+
+	// SSN (Optional)
+	if m.SubsystemNumber != 0 {
+		writeUint8(buf, 0x8003, m.SubsystemNumber)
+	}
+
+	// SMI (Optional)
+	if m.SMI != 0 {
+		writeUint8(buf, 0x0112, m.SMI)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x02, 0x02, buf.Bytes(), nil
+}
+
+func (m *DAVA) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0012:
+		// Affected Point Code
+		m.AffectedPointCode, e = readAPC(r, l)
+	case 0x8003:
+		// SSN (Optional)
+		m.SubsystemNumber, e = readUint8(r, l)
+	case 0x0112:
+		// SMI (Optional)
+		m.SMI, e = readUint8(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+DAUD is Destination State Audit message. (Message type = 0x03)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0012          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC 1                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      * Affected Point Code                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8003          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                 Reserved                      |   SSN value   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010c          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|             Cause             |            User               |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0004          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type DAUD struct {
+	RoutingContext    []uint32
+	NetworkAppearance *uint32
+	AffectedPointCode []PointCode
+	SubsystemNumber   uint8
+	Cause             uint16
+	User              uint16
+	InfoString        string
+
+	Unknown Parameters
+}
+
+func (m *DAUD) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// Affected Point Code
+	writeAPC(buf, m.AffectedPointCode)
+
+	// SSN (Optional)
+	if m.SubsystemNumber != 0 {
+		writeUint8(buf, 0x8003, m.SubsystemNumber)
+	}
+
+	// User/Cause (Optional)
+	if m.User != 0 {
+		writeUserCause(buf, m.Cause, m.User)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x02, 0x03, buf.Bytes(), nil
+}
+
+func (m *DAUD) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0012:
+		// Affected Point Code
+		m.AffectedPointCode, e = readAPC(r, l)
+	case 0x8003:
+		// SSN (Optional)
+		m.SubsystemNumber, e = readUint8(r, l)
+	case 0x010C:
+		// User/Cause
+		m.Cause, m.User, e = readUserCause(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+SCON is  Signalling Congestion message. (Message type = 0x04)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0012          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC 1                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      * Affected Point Code                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8003          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                 Reserved                      |   SSN value   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0118          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                     * Congestion Level                        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0112          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                    Reserved                   |      SMI      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0004          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type SCON struct {
+	RoutingContext    []uint32
+	NetworkAppearance *uint32
+	AffectedPointCode []PointCode
+	SubsystemNumber   uint8
+	CongestionLevel   uint32
+	SMI               uint8
+	InfoString        string
+
+	Unknown Parameters
+}
+
+func (m *SCON) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// Affected Point Code
+	writeAPC(buf, m.AffectedPointCode)
+
+	// SSN (Optional)
+	if m.SubsystemNumber != 0 {
+		writeUint8(buf, 0x8003, m.SubsystemNumber)
+	}
+
+	// Congestion Level
+	writeUint32(buf, 0x0118, m.CongestionLevel)
+
+	// SMI (Optional)
+	if m.SMI != 0 {
+		writeUint8(buf, 0x0112, m.SMI)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x02, 0x04, buf.Bytes(), nil
+}
+
+func (m *SCON) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0012:
+		// Affected Point Code
+		m.AffectedPointCode, e = readAPC(r, l)
+	case 0x8003:
+		// SSN (Optional)
+		m.SubsystemNumber, e = readUint8(r, l)
+	case 0x0118:
+		// Congestion Level
+		m.CongestionLevel, e = readUint32(r, l)
+	case 0x0112:
+		// SMI (Optional)
+		m.SMI, e = readUint8(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+DUPU is Destination User Part Unavailable. (Message type = 0x05)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0012          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC 1                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      * Affected Point Code                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010c          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           * Cause             |          * User               |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0004          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	\                                                               \
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type DUPU struct {
+	RoutingContext    []uint32
+	NetworkAppearance *uint32
+	AffectedPointCode []PointCode
+	Cause             uint16
+	User              uint16
+	InfoString        string
+
+	Unknown Parameters
+}
+
+func (m *DUPU) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// Affected Point Code
+	writeAPC(buf, m.AffectedPointCode)
+
+	// User/Cause
+	writeUserCause(buf, m.Cause, m.User)
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x02, 0x05, buf.Bytes(), nil
+}
+
+func (m *DUPU) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0012:
+		// Affected Point Code
+		m.AffectedPointCode, e = readAPC(r, l)
+	case 0x010C:
+		// User/Cause
+		m.Cause, m.User, e = readUserCause(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+/*
+DRST is Destination Restricted message. (Message type = 0x06)
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0006          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                       Routing Context                         /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x010D          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Network Appearance                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0012          |            Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|    Mask       |                 Affected PC 1                 |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                      * Affected Point Code                    /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x8003          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                 Reserved                      |   SSN value   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0112          |            Length = 8         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                    Reserved                   |      SMI      |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|         Tag = 0x0004          |             Length            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                          Info String                          /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type DRST struct {
+	RoutingContext    []uint32
+	NetworkAppearance *uint32
+	AffectedPointCode []PointCode
+	SubsystemNumber   uint8
+	SMI               uint8
+	InfoString        string
+
+	Unknown Parameters
+}
+
+func (m *DRST) marshal() (uint8, uint8, []byte, error) {
+	buf := new(bytes.Buffer)
+
+	// Routing Context (Optional)
+	if len(m.RoutingContext) != 0 {
+		writeRoutingContext(buf, m.RoutingContext)
+	}
+
+	// Network Appearance (Optional)
+	if m.NetworkAppearance != nil {
+		writeUint32(buf, 0x010D, *m.NetworkAppearance)
+	}
+
+	// Affected Point Code
+	writeAPC(buf, m.AffectedPointCode)
+
+	// SSN (Optional)
+	if m.SubsystemNumber != 0 {
+		writeUint8(buf, 0x8003, m.SubsystemNumber)
+	}
+
+	// SMI (Optional)
+	if m.SMI != 0 {
+		writeUint8(buf, 0x0112, m.SMI)
+	}
+
+	// Info String (Optional)
+	if len(m.InfoString) != 0 {
+		writeInfo(buf, m.InfoString)
+	}
+
+	// Unknown parameters
+	m.Unknown.marshal(buf)
+	return 0x02, 0x06, buf.Bytes(), nil
+}
+
+func (m *DRST) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	switch t {
+	case 0x0006:
+		// Routing Context (Optional)
+		m.RoutingContext, e = readRoutingContext(r, l)
+	case 0x0012:
+		// Affected Point Code
+		m.AffectedPointCode, e = readAPC(r, l)
+	case 0x8003:
+		// SSN (Optional)
+		m.SubsystemNumber, e = readUint8(r, l)
+	case 0x0112:
+		// SMI (Optional)
+		m.SMI, e = readUint8(r, l)
+	case 0x0004:
+		// Info String (Optional)
+		m.InfoString, e = readInfo(r, l)
+	case 0x010D:
+		// Network Appearance (Optional)
+		m.NetworkAppearance, e = readUint32Ptr(r, l)
+	default:
+		e = m.Unknown.unmarshal(t, l, r)
+	}
+	return
+}
+
+func writeUserCause(w io.Writer, cause, user uint16) {
+	binary.Write(w, binary.BigEndian, uint16(0x010C))
+	binary.Write(w, binary.BigEndian, uint16(8))
+	binary.Write(w, binary.BigEndian, cause)
+	binary.Write(w, binary.BigEndian, user)
+}
+
+func readUserCause(r io.ReadSeeker, l uint16) (cause, user uint16, e error) {
+	if l != 4 {
+		e = ErrBadLength
+	} else if e = binary.Read(r, binary.BigEndian, &cause); e == nil {
+		e = binary.Read(r, binary.BigEndian, &user)
+	}
+	return
+}
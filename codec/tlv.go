@@ -0,0 +1,205 @@
+package codec
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Parameter is TLV parameter of message.
+// Value does not include the padding.
+type Parameter struct {
+	Tag   uint16
+	Value []byte
+}
+
+// NewUint32 returns parameter of tag t with 32bit value v.
+func NewUint32(t uint16, v uint32) Parameter {
+	p := Parameter{Tag: t, Value: make([]byte, 4)}
+	binary.BigEndian.PutUint32(p.Value, v)
+	return p
+}
+
+// NewUint8 returns parameter of tag t with 8bit value v
+// in the last octet of 32bit field.
+func NewUint8(t uint16, v uint8) Parameter {
+	return Parameter{Tag: t, Value: []byte{0, 0, 0, v}}
+}
+
+// NewUint32List returns parameter of tag t with list of 32bit value v.
+func NewUint32List(t uint16, v []uint32) Parameter {
+	p := Parameter{Tag: t, Value: make([]byte, 4*len(v))}
+	for i, c := range v {
+		binary.BigEndian.PutUint32(p.Value[i*4:], c)
+	}
+	return p
+}
+
+// Uint32 returns 32bit value of the parameter.
+func (p Parameter) Uint32() (uint32, error) {
+	if len(p.Value) != 4 {
+		return 0, ErrBadLength
+	}
+	return binary.BigEndian.Uint32(p.Value), nil
+}
+
+// Uint8 returns 8bit value in the last octet of 32bit field.
+func (p Parameter) Uint8() (uint8, error) {
+	if len(p.Value) != 4 {
+		return 0, ErrBadLength
+	}
+	return p.Value[3], nil
+}
+
+// Uint32List returns list of 32bit value of the parameter.
+func (p Parameter) Uint32List() ([]uint32, error) {
+	if len(p.Value)%4 != 0 {
+		return nil, ErrBadLength
+	}
+	v := make([]uint32, len(p.Value)/4)
+	for i := range v {
+		v[i] = binary.BigEndian.Uint32(p.Value[i*4:])
+	}
+	return v, nil
+}
+
+func (p Parameter) marshal(w io.Writer) {
+	binary.Write(w, binary.BigEndian, p.Tag)
+	binary.Write(w, binary.BigEndian, uint16(4+len(p.Value)))
+	w.Write(p.Value)
+	if len(p.Value)%4 != 0 {
+		w.Write(make([]byte, 4-len(p.Value)%4))
+	}
+}
+
+func readParameter(t, l uint16, r io.ReadSeeker) (p Parameter, e error) {
+	p.Tag = t
+	p.Value, e = readData(r, l)
+	return
+}
+
+// Parameters is list of parameters.
+// Unknown or vendor-specific parameters of a message are kept in it
+// on decode, and written after known parameters on encode.
+type Parameters []Parameter
+
+// Get returns the first parameter of tag t.
+func (ps Parameters) Get(t uint16) (Parameter, bool) {
+	for _, p := range ps {
+		if p.Tag == t {
+			return p, true
+		}
+	}
+	return Parameter{}, false
+}
+
+func (ps Parameters) marshal(w io.Writer) {
+	for _, p := range ps {
+		p.marshal(w)
+	}
+}
+
+func (ps *Parameters) unmarshal(t, l uint16, r io.ReadSeeker) (e error) {
+	var p Parameter
+	if p, e = readParameter(t, l, r); e == nil {
+		*ps = append(*ps, p)
+	}
+	return
+}
+
+func writeInfo(w io.Writer, info string) {
+	d := []byte(info)
+	if len(d) > 255 {
+		d = d[:255]
+	}
+	writeBytes(w, 0x0004, d)
+}
+
+func readInfo(r io.ReadSeeker, l uint16) (v string, e error) {
+	var d []byte
+	if d, e = readData(r, l); e == nil {
+		v = string(d)
+	}
+	return
+}
+
+func writeRoutingContext(w io.Writer, cx []uint32) {
+	NewUint32List(0x0006, cx).marshal(w)
+}
+
+func readRoutingContext(r io.ReadSeeker, l uint16) (v []uint32, e error) {
+	var p Parameter
+	if p, e = readParameter(0x0006, l, r); e == nil {
+		v, e = p.Uint32List()
+	}
+	return
+}
+
+func writeUint32(w io.Writer, t uint16, v uint32) {
+	NewUint32(t, v).marshal(w)
+}
+
+func readUint32(r io.ReadSeeker, l uint16) (v uint32, e error) {
+	var p Parameter
+	if p, e = readParameter(0, l, r); e == nil {
+		v, e = p.Uint32()
+	}
+	return
+}
+
+func writeUint8(w io.Writer, t uint16, v uint8) {
+	NewUint8(t, v).marshal(w)
+}
+
+func readUint8(r io.ReadSeeker, l uint16) (v uint8, e error) {
+	var p Parameter
+	if p, e = readParameter(0, l, r); e == nil {
+		v, e = p.Uint8()
+	}
+	return
+}
+
+func writeData(w io.Writer, d []byte) {
+	writeBytes(w, 0x010B, d)
+}
+
+func writeDiagnostic(w io.Writer, d []byte) {
+	if len(d) > 0xfff0 {
+		d = d[:0xfff0]
+	}
+	writeBytes(w, 0x0007, d)
+}
+
+// writeBytes writes TLV parameter of tag t with value d and padding.
+func writeBytes(w io.Writer, t uint16, d []byte) {
+	Parameter{Tag: t, Value: d}.marshal(w)
+}
+
+func readData(r io.ReadSeeker, l uint16) (d []byte, e error) {
+	d = make([]byte, l)
+	e = readFull(r, d)
+	return
+}
+
+// readFull reads exactly len(b) bytes from r.
+func readFull(r io.Reader, b []byte) error {
+	if _, e := io.ReadFull(r, b); e != nil {
+		return ErrTruncated
+	}
+	return nil
+}
+
+func readUint32Ptr(r io.ReadSeeker, l uint16) (v *uint32, e error) {
+	var tmp uint32
+	if tmp, e = readUint32(r, l); e == nil {
+		v = &tmp
+	}
+	return
+}
+
+func readUint8Ptr(r io.ReadSeeker, l uint16) (v *uint8, e error) {
+	var tmp uint8
+	if tmp, e = readUint8(r, l); e == nil {
+		v = &tmp
+	}
+	return
+}
@@ -0,0 +1,61 @@
+package xua
+
+import "github.com/fkgi/xua/codec"
+
+// ErrorCode is Error Code parameter of ERR message.
+// It can be compared with error returned from ASP procedures by errors.Is.
+type ErrorCode = codec.ErrorCode
+
+// Error Code values defined in RFC 3868.
+const (
+	ErrInvalidVersion                 = codec.ErrInvalidVersion
+	ErrUnsupportedMessageClass        = codec.ErrUnsupportedMessageClass
+	ErrUnsupportedMessageType         = codec.ErrUnsupportedMessageType
+	ErrUnsupportedTrafficHandlingMode = codec.ErrUnsupportedTrafficHandlingMode
+	ErrUnexpectedMessage              = codec.ErrUnexpectedMessage
+	ErrProtocolError                  = codec.ErrProtocolError
+	ErrInvalidStreamIdentifier        = codec.ErrInvalidStreamIdentifier
+	ErrRefusedManagementBlocking      = codec.ErrRefusedManagementBlocking
+	ErrASPIdentifierRequired          = codec.ErrASPIdentifierRequired
+	ErrInvalidASPIdentifier           = codec.ErrInvalidASPIdentifier
+	ErrInvalidParameterValue          = codec.ErrInvalidParameterValue
+	ErrParameterFieldError            = codec.ErrParameterFieldError
+	ErrUnexpectedParameter            = codec.ErrUnexpectedParameter
+	ErrDestinationStatusUnknown       = codec.ErrDestinationStatusUnknown
+	ErrInvalidNetworkAppearance       = codec.ErrInvalidNetworkAppearance
+	ErrMissingParameter               = codec.ErrMissingParameter
+	ErrInvalidRoutingContext          = codec.ErrInvalidRoutingContext
+	ErrNoConfiguredASForASP           = codec.ErrNoConfiguredASForASP
+	ErrSubsystemStatusUnknown         = codec.ErrSubsystemStatusUnknown
+	ErrInvalidLoadsharingLabel        = codec.ErrInvalidLoadsharingLabel
+)
+
+// Error is error notified by ERR message.
+// Code is available by errors.Is and *Error by errors.As.
+type Error struct {
+	Code              ErrorCode
+	RoutingContext    []uint32
+	AffectedPointCode []PointCode
+	NetworkAppearance *uint32
+	DiagnosticInfo    []byte
+}
+
+func (e *Error) Error() string {
+	return "ERR: " + e.Code.Error()
+}
+
+// Unwrap returns Code of the error.
+func (e *Error) Unwrap() error {
+	return e.Code
+}
+
+// Errors on decoding received message.
+var (
+	ErrTruncated  = codec.ErrTruncated
+	ErrBadLength  = codec.ErrBadLength
+	ErrBadPadding = codec.ErrBadPadding
+)
+
+// DecodeError is error on decoding received message.
+// Err is ErrTruncated, ErrBadLength, ErrBadPadding or ErrorCode.
+type DecodeError = codec.DecodeError
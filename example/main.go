@@ -58,7 +58,7 @@ func main() {
 		},
 		func() {
 			time.Sleep(time.Second)
-			xua.Write(
+			e := xua.Write(
 				xua.SCCPAddress{
 					NatureOfAddress: xua.NAI_International,
 					NumberingPlan:   xua.NPI_E164,
@@ -69,6 +69,9 @@ func main() {
 					NumberingPlan:   xua.NPI_E164,
 					GlobalTitle:     "67890",
 					SubsystemNumber: 0x07}, make([]byte, 10))
+			if e != nil {
+				log.Print("write failed: ", e)
+			}
 			time.Sleep(time.Second)
 			xua.Close()
 		},
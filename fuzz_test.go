@@ -0,0 +1,50 @@
+package xua
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// seedCorpus returns messages in the seed corpus of codec package.
+// Lines starting with # are comments.
+func seedCorpus(f *testing.F) (ret [][]byte) {
+	b, e := os.ReadFile(filepath.Join("codec", "testdata", "corpus.txt"))
+	if e != nil {
+		f.Fatal(e)
+	}
+	for _, l := range strings.Split(string(b), "\n") {
+		if l = strings.TrimSpace(l); l == "" || l[0] == '#' {
+			continue
+		}
+		m, e := hex.DecodeString(l)
+		if e != nil {
+			f.Fatal(e)
+		}
+		ret = append(ret, m)
+	}
+	return
+}
+
+// FuzzReadHandler checks that any received message is handled without panic.
+func FuzzReadHandler(f *testing.F) {
+	for _, b := range seedCorpus(f) {
+		f.Add(b, uint16(0))
+		f.Add(b, uint16(1))
+	}
+	f.Fuzz(func(t *testing.T, b []byte, s uint16) {
+		readHandler(b, s)
+
+		m, e := decodeMessage(b)
+		if e == nil && m == nil {
+			t.Fatal("no message without error")
+		}
+		if e != nil {
+			if _, ok := e.(*DecodeError); !ok {
+				t.Fatalf("untyped decode error %v", e)
+			}
+		}
+	})
+}
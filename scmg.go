@@ -0,0 +1,165 @@
+package xua
+
+import "github.com/fkgi/xua/codec"
+
+/*
+SCMG: SCCP Management messages
+carried in CLDT with called party SSN = 1
+
+	+-+-+-+-+-+-+-+-+
+	| Format ID     |
+	+-+-+-+-+-+-+-+-+
+	| Affected SSN  |
+	+-+-+-+-+-+-+-+-+
+	| Affected PC   |  2 octets (ITU, Japan) or 3 octets (ANSI)
+	/               /
+	+-+-+-+-+-+-+-+-+
+	| SMI           |
+	+-+-+-+-+-+-+-+-+
+*/
+
+const scmgSSN = 0x01
+
+// SCMG format identifiers.
+const (
+	scmgSSA = 0x01 // Subsystem Allowed
+	scmgSSP = 0x02 // Subsystem Prohibited
+	scmgSST = 0x03 // Subsystem Status Test
+)
+
+var (
+	// LocalPointCode is own point code used in SCMG messages.
+	LocalPointCode PointCode
+	// ConcernedPointCode is point codes notified by SSA or SSP
+	// when state of local subsystem is changed.
+	ConcernedPointCode []PointCode
+	// HandleSubsystemStatus is called when state of
+	// remote subsystem is changed by received SSA or SSP.
+	HandleSubsystemStatus func(pc PointCode, ssn uint8, allowed bool)
+
+	remoteSubsystem = map[subsystem]bool{}
+)
+
+type subsystem struct {
+	pc  uint32
+	ssn uint8
+}
+
+type scmg struct {
+	format uint8
+	ssn    uint8
+	pc     PointCode
+	smi    uint8
+}
+
+func pcLen(v PointCodeVariant) int {
+	if v == PC_ANSI {
+		return 3
+	}
+	return 2
+}
+
+func (m scmg) marshal() []byte {
+	b := []byte{m.format, m.ssn}
+	for i := 0; i < pcLen(m.pc.Variant); i++ {
+		b = append(b, byte(m.pc.Value>>(8*i)))
+	}
+	return append(b, m.smi)
+}
+
+func unmarshalSCMG(b []byte) (m scmg, ok bool) {
+	m.pc.Variant = codec.DefaultPointCodeVariant
+	l := pcLen(m.pc.Variant)
+	if len(b) < 3+l {
+		return
+	}
+	m.format = b[0]
+	m.ssn = b[1]
+	for i := 0; i < l; i++ {
+		m.pc.Value |= uint32(b[2+i]) << (8 * i)
+	}
+	if m.pc.Variant == PC_ITU {
+		// 2 bits spare
+		m.pc.Value &= 0x3fff
+	}
+	m.smi = b[2+l]
+	return m, true
+}
+
+// broadcastSCMG sends SSA or SSP of local subsystem ssn to ConcernedPointCode
+// in each Network Appearance of RoutingContext.
+func broadcastSCMG(ssn uint8, allowed bool) {
+	f := uint8(scmgSSP)
+	if allowed {
+		f = scmgSSA
+	}
+	data := scmg{format: f, ssn: ssn, pc: LocalPointCode}.marshal()
+	for _, rc := range splitRoutingContext(RoutingContext) {
+		for _, pc := range ConcernedPointCode {
+			putEvent(&CLDT{
+				CLDT: codec.CLDT{
+					RoutingContext:    rc,
+					NetworkAppearance: networkAppearance(rc),
+					SourceAddress: SCCPAddress{
+						RoutingIndicator: RI_SSNPC,
+						PointCode:        LocalPointCode,
+						SubsystemNumber:  scmgSSN},
+					DestinationAddress: SCCPAddress{
+						RoutingIndicator: RI_SSNPC,
+						PointCode:        pc,
+						SubsystemNumber:  scmgSSN},
+					Data: data},
+				tx: true})
+		}
+	}
+}
+
+// SubsystemAllowed returns state of remote subsystem notified by SSA or SSP.
+// Subsystem is allowed if no state is notified.
+func SubsystemAllowed(pc PointCode, ssn uint8) bool {
+	subsystemLock.RLock()
+	defer subsystemLock.RUnlock()
+	if a, ok := remoteSubsystem[subsystem{pc: pc.Value, ssn: ssn}]; ok {
+		return a
+	}
+	return true
+}
+
+// handleSCMG handles SCMG message in received CLDT.
+func handleSCMG(m *CLDT) {
+	msg, ok := unmarshalSCMG(m.Data)
+	if !ok {
+		return
+	}
+
+	switch msg.format {
+	case scmgSST:
+		if msg.ssn != scmgSSN && !localAllowed(msg.ssn) {
+			// no answer for prohibited subsystem
+			return
+		}
+		msg.format = scmgSSA
+		ans := &CLDT{
+			CLDT: codec.CLDT{
+				RoutingContext:     m.RoutingContext,
+				NetworkAppearance:  m.NetworkAppearance,
+				SourceAddress:      m.DestinationAddress,
+				DestinationAddress: m.SourceAddress,
+				Data:               msg.marshal()},
+			tx: true}
+		ans.handleMessageTx()
+	case scmgSSA, scmgSSP:
+		allowed := msg.format == scmgSSA
+		k := subsystem{pc: msg.pc.Value, ssn: msg.ssn}
+		subsystemLock.Lock()
+		old, ok := remoteSubsystem[k]
+		remoteSubsystem[k] = allowed
+		subsystemLock.Unlock()
+		if !ok {
+			old = true
+		}
+		if old != allowed && HandleSubsystemStatus != nil {
+			HandleSubsystemStatus(msg.pc, msg.ssn, allowed)
+		}
+	}
+}
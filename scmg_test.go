@@ -0,0 +1,187 @@
+package xua
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fkgi/xua/codec"
+)
+
+// resetSubsystem removes local and remote subsystem state after the test.
+func resetSubsystem(t *testing.T) {
+	t.Cleanup(func() {
+		subsystemLock.Lock()
+		for _, s := range localSubsystem {
+			if s.queue != nil {
+				close(s.queue)
+			}
+		}
+		localSubsystem = map[uint8]localState{}
+		remoteSubsystem = map[subsystem]bool{}
+		subsystemLock.Unlock()
+		LocalPointCode = PointCode{}
+		ConcernedPointCode = nil
+		HandleSubsystemStatus = nil
+	})
+}
+
+// scmgCLDT returns CLDT with SCMG message from remote to local SCMG.
+func scmgCLDT(format, ssn uint8, pc PointCode) *codec.CLDT {
+	return &codec.CLDT{
+		SourceAddress: SCCPAddress{
+			RoutingIndicator: RI_SSNPC,
+			PointCode:        PointCode{Value: 200},
+			SubsystemNumber:  scmgSSN},
+		DestinationAddress: SCCPAddress{
+			RoutingIndicator: RI_SSNPC,
+			PointCode:        LocalPointCode,
+			SubsystemNumber:  scmgSSN},
+		Data: scmg{format: format, ssn: ssn, pc: pc}.marshal()}
+}
+
+// recvSCMG returns SCMG message in CLDT received by SG.
+func recvSCMG(t *testing.T, sg *fakeSG) (*codec.CLDT, scmg) {
+	t.Helper()
+	m, ok := sg.recv(t).(*codec.CLDT)
+	if !ok {
+		t.Fatal("CLDT is not sent")
+	}
+	if m.DestinationAddress.SubsystemNumber != scmgSSN ||
+		m.SourceAddress.SubsystemNumber != scmgSSN {
+		t.Fatalf("not SCMG %+v", m)
+	}
+	s, ok := unmarshalSCMG(m.Data)
+	if !ok {
+		t.Fatalf("invalid SCMG % x", m.Data)
+	}
+	return m, s
+}
+
+func TestPipeSST(t *testing.T) {
+	resetSubsystem(t)
+	LocalPointCode = PointCode{Value: 100}
+	RegisterSubsystem(8, func(cgpa, cdpa SCCPAddress, b []byte) {})
+
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	// SST for allowed subsystem is answered by SSA
+	sg.write(scmgCLDT(scmgSST, 8, LocalPointCode))
+	m, s := recvSCMG(t, sg)
+	if s.format != scmgSSA || s.ssn != 8 || s.pc.Value != LocalPointCode.Value {
+		t.Errorf("invalid answer %+v", s)
+	}
+	if m.DestinationAddress.PointCode.Value != 200 ||
+		m.SourceAddress.PointCode.Value != LocalPointCode.Value {
+		t.Errorf("addresses are not swapped %+v", m)
+	}
+
+	// SST for SCMG itself is always answered
+	sg.write(scmgCLDT(scmgSST, scmgSSN, LocalPointCode))
+	if _, s = recvSCMG(t, sg); s.format != scmgSSA || s.ssn != scmgSSN {
+		t.Errorf("invalid answer %+v", s)
+	}
+
+	// no answer for prohibited or unknown subsystem
+	SetSubsystem(8, false)
+	sg.write(scmgCLDT(scmgSST, 8, LocalPointCode))
+	sg.write(scmgCLDT(scmgSST, 9, LocalPointCode))
+	sg.noRecv(t)
+
+	closeASP(t, done)
+	sg.recv(t)
+}
+
+func TestPipeSSASSP(t *testing.T) {
+	resetSubsystem(t)
+	type status struct {
+		pc      uint32
+		ssn     uint8
+		allowed bool
+	}
+	ch := make(chan status, 4)
+	HandleSubsystemStatus = func(pc PointCode, ssn uint8, allowed bool) {
+		ch <- status{pc.Value, ssn, allowed}
+	}
+	wait := func(want status) {
+		t.Helper()
+		select {
+		case s := <-ch:
+			if s != want {
+				t.Errorf("status %+v, want %+v", s, want)
+			}
+		case <-time.After(time.Second * 3):
+			t.Fatal("HandleSubsystemStatus is not called")
+		}
+	}
+
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	pc := PointCode{Value: 200}
+	if !SubsystemAllowed(pc, 6) {
+		t.Error("unknown subsystem is not allowed")
+	}
+
+	sg.write(scmgCLDT(scmgSSP, 6, pc))
+	wait(status{200, 6, false})
+	if SubsystemAllowed(pc, 6) {
+		t.Error("subsystem is allowed after SSP")
+	}
+	if !SubsystemAllowed(pc, 7) || !SubsystemAllowed(PointCode{Value: 201}, 6) {
+		t.Error("SSP changes other subsystem")
+	}
+
+	// same state is not notified
+	sg.write(scmgCLDT(scmgSSP, 6, pc))
+	sg.write(scmgCLDT(scmgSSA, 6, pc))
+	wait(status{200, 6, true})
+	if !SubsystemAllowed(pc, 6) {
+		t.Error("subsystem is prohibited after SSA")
+	}
+	sg.noRecv(t)
+
+	closeASP(t, done)
+	sg.recv(t)
+}
+
+func TestPipeBroadcastSCMG(t *testing.T) {
+	resetSubsystem(t)
+	LocalPointCode = PointCode{Value: 100}
+	ConcernedPointCode = []PointCode{{Value: 200}, {Value: 201}}
+
+	sg := newFakeSG(t)
+	done := serveASP(t, func([]byte) {})
+	sg.recv(t)
+	sg.recv(t)
+
+	// SSA is sent to all concerned point codes when subsystem is registered
+	RegisterSubsystem(8, func(cgpa, cdpa SCCPAddress, b []byte) {})
+	for _, pc := range ConcernedPointCode {
+		m, s := recvSCMG(t, sg)
+		if s.format != scmgSSA || s.ssn != 8 || s.pc.Value != 100 ||
+			m.DestinationAddress.PointCode.Value != pc.Value {
+			t.Errorf("invalid SSA %+v to %d", s, m.DestinationAddress.PointCode.Value)
+		}
+	}
+
+	// SSP is sent when subsystem is deregistered
+	DeregisterSubsystem(8)
+	for range ConcernedPointCode {
+		if _, s := recvSCMG(t, sg); s.format != scmgSSP || s.ssn != 8 {
+			t.Errorf("invalid SSP %+v", s)
+		}
+	}
+
+	// no SSP for unknown subsystem
+	DeregisterSubsystem(8)
+	DeregisterSubsystem(9)
+	sg.noRecv(t)
+
+	closeASP(t, done)
+	sg.recv(t)
+}
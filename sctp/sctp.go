@@ -2,10 +2,15 @@ package sctp
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"errors"
 	"io"
 	"net"
+	"sync"
 	"syscall"
+	"time"
+	"unsafe"
 )
 
 var (
@@ -14,15 +19,118 @@ var (
 	// PeerAddr is SCTP peer address
 	PeerAddr *SCTPAddr
 
-	// ProtocolID of data layer
+	// ProtocolID of data layer in network byte order (SUA = 4)
 	ProtocolID uint32 = 67108864
 
 	sock    int
 	assocID assocT
 
+	outStreams uint16
+	inStreams  uint16
+
 	TTL uint32 = 0
+
+	// Option is SCTP socket option applied before bind/connect
+	Option SockOpt
+
+	// ReadBufferSize is buffer size of each receive call
+	ReadBufferSize = 1500
+	// MaxMessageSize is maximum size of reassembled message.
+	// Larger message is discarded.
+	MaxMessageSize = 65536
+
+	dryMutex sync.Mutex
+	dryWait  []chan struct{}
 )
 
+// SockOpt is SCTP socket option parameters.
+// Zero value of each parameter means system default value.
+type SockOpt struct {
+	// RTO parameters (SCTP_RTOINFO)
+	// Durations are rounded up to ms and limited to 0xffffffff ms
+	RtoInitial time.Duration
+	RtoMax     time.Duration
+	RtoMin     time.Duration
+
+	// peer address parameters (SCTP_PEER_ADDR_PARAMS)
+	// HeartbeatInterval is rounded up and limited like RTO parameters
+	HeartbeatInterval time.Duration
+	PathMaxRetrans    uint16
+
+	// INIT parameters (SCTP_INITMSG)
+	OutStreams      uint16
+	MaxInStreams    uint16
+	MaxInitAttempts uint16
+	// MaxInitTimeout is limited to 65535 ms
+	MaxInitTimeout time.Duration
+
+	// NoDelay disables Nagle like algorithm (SCTP_NODELAY)
+	NoDelay bool
+
+	// socket buffer size (SO_SNDBUF, SO_RCVBUF)
+	SendBuffer int
+	RecvBuffer int
+}
+
+// msec returns d in ms limited to max.
+// Positive d less than 1 ms is rounded up to 1 ms
+// so that it is not taken as system default.
+func msec(d time.Duration, max uint32) uint32 {
+	if d <= 0 {
+		return 0
+	}
+	t := (d + time.Millisecond - 1) / time.Millisecond
+	if t > time.Duration(max) {
+		return max
+	}
+	return uint32(t)
+}
+
+func (o SockOpt) apply(fd int) (e error) {
+	if o.RtoInitial != 0 || o.RtoMax != 0 || o.RtoMin != 0 {
+		e = setRtoInfo(fd,
+			msec(o.RtoInitial, 0xffffffff),
+			msec(o.RtoMax, 0xffffffff),
+			msec(o.RtoMin, 0xffffffff))
+		if e != nil {
+			return
+		}
+	}
+	if o.HeartbeatInterval != 0 || o.PathMaxRetrans != 0 {
+		e = setPeerAddrParams(fd,
+			msec(o.HeartbeatInterval, 0xffffffff),
+			o.PathMaxRetrans)
+		if e != nil {
+			return
+		}
+	}
+	if o.OutStreams != 0 || o.MaxInStreams != 0 ||
+		o.MaxInitAttempts != 0 || o.MaxInitTimeout != 0 {
+		e = setInitMsg(fd,
+			o.OutStreams,
+			o.MaxInStreams,
+			o.MaxInitAttempts,
+			uint16(msec(o.MaxInitTimeout, 0xffff)))
+		if e != nil {
+			return
+		}
+	}
+	if o.NoDelay {
+		if e = setNoDelay(fd); e != nil {
+			return
+		}
+	}
+	if o.SendBuffer != 0 {
+		if e = setSockBuf(fd, syscall.SO_SNDBUF, o.SendBuffer); e != nil {
+			return
+		}
+	}
+	if o.RecvBuffer != 0 {
+		e = setSockBuf(fd, syscall.SO_RCVBUF, o.RecvBuffer)
+	}
+	return
+}
+
 type sndrcvInfo struct {
 	stream     uint16
 	ssn        uint16
@@ -35,7 +143,10 @@ type sndrcvInfo struct {
 	assocID    assocT
 }
 
-func Serve(handleData func([]byte), handleUp, handleDown func()) (e error) {
+// Serve connects to PeerAddr and handles received data
+// with its stream number and payload protocol ID.
+// It returns when the association is lost or shutdown.
+func Serve(handleData func([]byte, uint16, uint32), handleUp, handleDown func()) (e error) {
 	// create SCTP connection socket
 	if LocalAddr.IP[0].To4() != nil && PeerAddr.IP[0].To4() != nil {
 		sock, e = sockOpenV4()
@@ -63,9 +174,18 @@ func Serve(handleData func([]byte), handleUp, handleDown func()) (e error) {
 		return
 	}
 
+	// set socket options
+	if e = Option.apply(sock); e != nil {
+		sockClose(sock)
+		e = &net.OpError{
+			Op: "setsockopt", Net: "sctp",
+			Addr: LocalAddr, Err: e}
+		return
+	}
+
 	// bind SCTP connection to LocalAddr
 	ptr, n := LocalAddr.rawAddr()
-	if e = sctpBindx(sock, ptr, n); e != nil {
+	if e = sctpBindx(sock, ptr, n, sctpBindxAddAddr); e != nil {
 		sockClose(sock)
 		e = &net.OpError{
 			Op: "bind", Net: "sctp",
@@ -84,7 +204,11 @@ func Serve(handleData func([]byte), handleUp, handleDown func()) (e error) {
 	}
 
 	// receive message
-	buf := make([]byte, 1500)
+	buf := make([]byte, ReadBufferSize)
+	msg := make([]byte, 0, ReadBufferSize)
+	discard := false
+	ntf := make([]byte, 0, ReadBufferSize)
+	ntfDiscard := false
 	info := sndrcvInfo{}
 	flag := 0
 
@@ -99,15 +223,54 @@ func Serve(handleData func([]byte), handleUp, handleDown func()) (e error) {
 		}
 
 		if flag&msgNotification != msgNotification {
-			handleData(buf[:n])
+			// reassemble partial delivery
+			if discard || len(msg)+n > MaxMessageSize {
+				discard = true
+				msg = msg[:0]
+			} else {
+				msg = append(msg, buf[:n]...)
+			}
+			if !endOfRecord(flag) {
+				continue
+			}
+			if !discard {
+				handleData(msg, info.stream, ntohl(info.ppid))
+			}
+			discard = false
+			msg = msg[:0]
+			continue
+		}
+		// reassemble partial delivery of notification
+		if ntfDiscard || len(ntf)+n > MaxMessageSize {
+			ntfDiscard = true
+			ntf = ntf[:0]
+		} else {
+			ntf = append(ntf, buf[:n]...)
+		}
+		if !endOfRecord(flag) {
+			continue
+		}
+		if ntfDiscard {
+			ntfDiscard = false
 			continue
 		}
 
-		r := bytes.NewReader(buf[:n])
+		r := bytes.NewReader(ntf)
+		ntf = ntf[:0]
 		var chtype uint16
 		if e = binary.Read(r, binary.LittleEndian, &chtype); e != nil {
 			continue
 		}
+		if chtype == sctpPartialDeliveryEvent {
+			// partial delivery is aborted
+			discard = false
+			msg = msg[:0]
+			continue
+		}
+		if chtype == sctpSenderDryEvent {
+			notifyDry()
+			continue
+		}
 		if chtype != sctpAssocChange {
 			continue
 		}
@@ -131,7 +294,7 @@ func Serve(handleData func([]byte), handleUp, handleDown func()) (e error) {
 		if e = binary.Read(r, binary.LittleEndian, &state); e != nil {
 			continue
 		}
-		if _, e = r.Seek(int64(6), io.SeekCurrent); e != nil {
+		if _, e = r.Seek(int64(2), io.SeekCurrent); e != nil {
 			continue
 		}
 		/*
@@ -139,15 +302,14 @@ func Serve(handleData func([]byte), handleUp, handleDown func()) (e error) {
 			if e := binary.Read(r, binary.LittleEndian, &sacError); e != nil {
 				return
 			}
-			var outboundStreams uint16
-			if e := binary.Read(r, binary.LittleEndian, &outboundStreams); e != nil {
-				return
-			}
-			var inboundStreams uint16
-			if e := binary.Read(r, binary.LittleEndian, &inboundStreams); e != nil {
-				return
-			}
 		*/
+		var ostreams, istreams uint16
+		if e = binary.Read(r, binary.LittleEndian, &ostreams); e != nil {
+			continue
+		}
+		if e = binary.Read(r, binary.LittleEndian, &istreams); e != nil {
+			continue
+		}
 		var id assocT
 		if e = binary.Read(r, binary.LittleEndian, &id); e != nil {
 			continue
@@ -158,18 +320,94 @@ func Serve(handleData func([]byte), handleUp, handleDown func()) (e error) {
 
 		switch state {
 		case sctpCommUp:
+			outStreams, inStreams = ostreams, istreams
 			go handleUp()
 		case sctpCommLost, sctpShutdownComp:
 			go handleDown()
-			// case sctpRestart:
-			// case sctpCantStrAssoc:
+			sockClose(sock)
+			notifyDry()
+			return nil
+		case sctpRestart:
+			outStreams, inStreams = ostreams, istreams
+		case sctpCantStrAssoc:
+			sockClose(sock)
+			notifyDry()
+			return &net.OpError{
+				Op: "connect", Net: "sctp",
+				Source: LocalAddr, Addr: PeerAddr,
+				Err: errors.New("association setup failed")}
 		}
 	}
 
 	sockClose(sock)
+	notifyDry()
 	return
 }
 
+// notifyDry wakes up all Flush callers.
+func notifyDry() {
+	dryMutex.Lock()
+	for _, c := range dryWait {
+		close(c)
+	}
+	dryWait = nil
+	dryMutex.Unlock()
+}
+
+// Flush waits until all sent data is acknowledged by the peer
+// or the association is closed.
+func Flush() error {
+	return FlushContext(context.Background())
+}
+
+// FlushContext is Flush with ctx.
+// It returns ctx.Err() if ctx is done before the data is acknowledged.
+func FlushContext(ctx context.Context) error {
+	c := make(chan struct{})
+	dryMutex.Lock()
+	dryWait = append(dryWait, c)
+	dryMutex.Unlock()
+
+	if s, e := GetStatus(); e != nil {
+		cancelDry(c)
+		return e
+	} else if s.UnackedData == 0 && s.PendingData == 0 {
+		cancelDry(c)
+		return nil
+	}
+	select {
+	case <-c:
+		return nil
+	case <-ctx.Done():
+		cancelDry(c)
+		return ctx.Err()
+	}
+}
+
+// cancelDry removes c from Flush callers.
+func cancelDry(c chan struct{}) {
+	dryMutex.Lock()
+	for i, w := range dryWait {
+		if w == c {
+			dryWait = append(dryWait[:i], dryWait[i+1:]...)
+			break
+		}
+	}
+	dryMutex.Unlock()
+}
+
+// ntohl converts payload protocol ID in network byte order.
+func ntohl(v uint32) uint32 {
+	return binary.BigEndian.Uint32((*[4]byte)(unsafe.Pointer(&v))[:])
+}
+
+// Streams returns number of outbound and inbound streams
+// negotiated on the association.
+func Streams() (out, in uint16) {
+	return outStreams, inStreams
+}
+
+// Write sends data on stream s.
 func Write(b []byte, s uint16) (e error) {
 	buf := make([]byte, len(b))
 	copy(buf, b)
@@ -216,3 +454,27 @@ func Abort(reason string) (e error) {
 	}
 	return
 }
+
+// Transport is SCTP association between LocalAddr and PeerAddr.
+// It provides package level functions as methods.
+type Transport struct{}
+
+// Serve calls Serve.
+func (Transport) Serve(handleData func([]byte, uint16, uint32), handleUp, handleDown func()) error {
+	return Serve(handleData, handleUp, handleDown)
+}
+
+// Write calls Write.
+func (Transport) Write(b []byte, s uint16) error { return Write(b, s) }
+
+// Streams calls Streams.
+func (Transport) Streams() (out, in uint16) { return Streams() }
+
+// Flush calls FlushContext.
+func (Transport) Flush(ctx context.Context) error { return FlushContext(ctx) }
+
+// Close calls Close.
+func (Transport) Close() error { return Close() }
+
+// Abort calls Abort.
+func (Transport) Abort(reason string) error { return Abort(reason) }
@@ -143,3 +143,91 @@ func (a SCTPAddr) String() string {
 
 // Network returns the address's network name, "sctp".
 func (a SCTPAddr) Network() string { return "sctp" }
+
+func (a *SCTPAddr) rawStorage(ip net.IP) (s [128]byte, e error) {
+	if len(a.IP) == 0 || (ip.To4() != nil) != (a.IP[0].To4() != nil) {
+		e = &net.AddrError{
+			Err:  "mismatch of address version",
+			Addr: ip.String()}
+		return
+	}
+	ptr, n := (&SCTPAddr{IP: []net.IP{ip}, Port: a.Port}).rawAddr()
+	if n == 0 {
+		e = &net.AddrError{
+			Err:  "unknown address format",
+			Addr: ip.String()}
+		return
+	}
+	l := unsafe.Sizeof(syscall.RawSockaddrInet6{})
+	if ip.To4() != nil {
+		l = unsafe.Sizeof(syscall.RawSockaddrInet4{})
+	}
+	copy(s[:], unsafe.Slice((*byte)(ptr), l))
+	return
+}
+
+// SetPrimaryAddr sets peer address ip as primary destination path.
+func SetPrimaryAddr(ip net.IP) (e error) {
+	var addr [128]byte
+	if addr, e = PeerAddr.rawStorage(ip); e == nil {
+		e = setPrimaryAddr(sock, assocID, addr)
+	}
+	if e != nil {
+		e = &net.OpError{
+			Op: "setprim", Net: "sctp",
+			Source: LocalAddr, Addr: PeerAddr, Err: e}
+	}
+	return
+}
+
+// SetPeerPrimaryAddr requests the peer to use local address ip
+// as primary destination path.
+func SetPeerPrimaryAddr(ip net.IP) (e error) {
+	var addr [128]byte
+	if addr, e = LocalAddr.rawStorage(ip); e == nil {
+		e = setPeerPrimaryAddr(sock, assocID, addr)
+	}
+	if e != nil {
+		e = &net.OpError{
+			Op: "setpeerprim", Net: "sctp",
+			Source: LocalAddr, Addr: PeerAddr, Err: e}
+	}
+	return
+}
+
+// AddLocalAddr adds local address ip to the association.
+func AddLocalAddr(ip net.IP) (e error) {
+	if _, e = LocalAddr.rawStorage(ip); e == nil {
+		ptr, n := (&SCTPAddr{IP: []net.IP{ip}, Port: LocalAddr.Port}).rawAddr()
+		e = sctpBindx(sock, ptr, n, sctpBindxAddAddr)
+	}
+	if e != nil {
+		e = &net.OpError{
+			Op: "bindx", Net: "sctp",
+			Addr: LocalAddr, Err: e}
+		return
+	}
+	LocalAddr.IP = append(LocalAddr.IP, ip)
+	return
+}
+
+// RemoveLocalAddr removes local address ip from the association.
+func RemoveLocalAddr(ip net.IP) (e error) {
+	if _, e = LocalAddr.rawStorage(ip); e == nil {
+		ptr, n := (&SCTPAddr{IP: []net.IP{ip}, Port: LocalAddr.Port}).rawAddr()
+		e = sctpBindx(sock, ptr, n, sctpBindxRemAddr)
+	}
+	if e != nil {
+		e = &net.OpError{
+			Op: "bindx", Net: "sctp",
+			Addr: LocalAddr, Err: e}
+		return
+	}
+	for i, a := range LocalAddr.IP {
+		if a.Equal(ip) {
+			LocalAddr.IP = append(LocalAddr.IP[:i], LocalAddr.IP[i+1:]...)
+			break
+		}
+	}
+	return
+}
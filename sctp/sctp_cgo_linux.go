@@ -0,0 +1,100 @@
+//go:build !purego || 386
+
+package sctp
+
+/*
+#cgo CFLAGS: -Wall
+#cgo LDFLAGS: -lsctp
+
+#include <netinet/sctp.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+func setSockOpt(fd, opt int, p unsafe.Pointer, l uintptr) error {
+	n, e := C.setsockopt(
+		C.int(fd),
+		solSctp,
+		C.int(opt),
+		p,
+		C.socklen_t(l))
+	if int(n) < 0 {
+		return e
+	}
+	return nil
+}
+
+func getSockOpt(fd, opt int, p unsafe.Pointer, l *uintptr) error {
+	cl := C.socklen_t(*l)
+	n, e := C.getsockopt(
+		C.int(fd),
+		solSctp,
+		C.int(opt),
+		p,
+		&cl)
+	*l = uintptr(cl)
+	if int(n) < 0 {
+		return e
+	}
+	return nil
+}
+
+func sctpBindx(fd int, ptr unsafe.Pointer, l int, flag int) error {
+	n, e := C.sctp_bindx(
+		C.int(fd),
+		(*C.struct_sockaddr)(ptr),
+		C.int(l),
+		C.int(flag))
+	if int(n) < 0 {
+		return e
+	}
+	return nil
+}
+
+func sctpConnectx(fd int, ptr unsafe.Pointer, l int) (assocT, error) {
+	t := assocT(0)
+	n, e := C.sctp_connectx(
+		C.int(fd),
+		(*C.struct_sockaddr)(ptr),
+		C.int(l),
+		(*C.sctp_assoc_t)(unsafe.Pointer(&t)))
+	if int(n) < 0 {
+		return t, e
+	}
+	return t, nil
+}
+
+func sctpSend(fd int, b []byte, info *sndrcvInfo, flag int) (int, error) {
+	buf := unsafe.Pointer(nil)
+	if len(b) > 0 {
+		buf = unsafe.Pointer(&b[0])
+	}
+	n, e := C.sctp_send(
+		C.int(fd),
+		buf,
+		C.size_t(len(b)),
+		(*C.struct_sctp_sndrcvinfo)(unsafe.Pointer(info)),
+		C.int(flag))
+	if int(n) < 0 {
+		return -1, e
+	}
+	return int(n), nil
+}
+
+func sctpRecvmsg(fd int, b []byte, info *sndrcvInfo, flag *int) (int, error) {
+	n, e := C.sctp_recvmsg(
+		C.int(fd),
+		unsafe.Pointer(&b[0]),
+		C.size_t(len(b)),
+		nil,
+		nil,
+		(*C.struct_sctp_sndrcvinfo)(unsafe.Pointer(info)),
+		(*C.int)(unsafe.Pointer(flag)))
+	if int(n) < 0 {
+		return -1, e
+	}
+	return int(n), nil
+}
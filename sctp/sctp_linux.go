@@ -1,55 +1,78 @@
 package sctp
 
-/*
-#cgo CFLAGS: -Wall
-#cgo LDFLAGS: -lsctp
-
-#include <netinet/sctp.h>
-*/
-import "C"
-
 import (
+	"encoding/binary"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
+// values are defined in <linux/sctp.h>
 const (
-	sctpEoF       = C.SCTP_EOF
-	sctpAbort     = C.SCTP_ABORT
-	sctpUnordered = C.SCTP_UNORDERED
-	sctpAddrOver  = C.SCTP_ADDR_OVER
-
-	// SCTP_SENDALL = C.SCTP_SENDALL
-	// SCTP_EOR = C.SCTP_EOR
-
-	//SCTP_SACK_IMMEDIATELY = C.SCTP_SACK_IMMEDIATELY
-
-	// SOL_SCTP    = C.SOL_SCTP
-	// SCTP_EVENTS = C.SCTP_EVENTS
-
-	msgNotification              = C.MSG_NOTIFICATION
-	sctpDataIO                   = C.SCTP_DATA_IO_EVENT
-	sctpAssocChange              = C.SCTP_ASSOC_CHANGE
-	sctpPeerAddrChange           = C.SCTP_PEER_ADDR_CHANGE
-	sctpSendFailed               = C.SCTP_SEND_FAILED
-	sctpRemoteError              = C.SCTP_REMOTE_ERROR
-	sctpShutdownEvent            = C.SCTP_SHUTDOWN_EVENT
-	sctpPartialDeliveryEvent     = C.SCTP_PARTIAL_DELIVERY_EVENT
-	sctpAdaptationIndication     = C.SCTP_ADAPTATION_INDICATION
-	sctpAuthenticationIndication = C.SCTP_AUTHENTICATION_INDICATION
-	sctpSenderDryEvent           = C.SCTP_SENDER_DRY_EVENT
-	sctpStreamResetEvent         = C.SCTP_STREAM_RESET_EVENT
-	sctpAssocResetEvent          = C.SCTP_ASSOC_RESET_EVENT
-	sctpStreamChangeEvent        = C.SCTP_STREAM_CHANGE_EVENT
-
-	sctpCommUp       = C.SCTP_COMM_UP
-	sctpCommLost     = C.SCTP_COMM_LOST
-	sctpRestart      = C.SCTP_RESTART
-	sctpShutdownComp = C.SCTP_SHUTDOWN_COMP
-	sctpCantStrAssoc = C.SCTP_CANT_STR_ASSOC
+	solSctp = 132
+
+	sctpEoF       = syscall.MSG_FIN
+	sctpAbort     = 0x0004
+	sctpUnordered = 0x0001
+	sctpAddrOver  = 0x0002
+
+	sctpBindxAddAddr = 0x01
+	sctpBindxRemAddr = 0x02
+
+	// SCTP_SENDALL = 0x0040
+	// SCTP_EOR = syscall.MSG_EOR
+	// SCTP_SACK_IMMEDIATELY = 0x0008
+
+	sctpRtoInfo            = 0
+	sctpInitMsg            = 2
+	sctpNoDelay            = 3
+	sctpSetPeerPrimaryAddr = 5
+	sctpPrimaryAddr        = 6
+	sctpPeerAddrParams     = 9
+	sctpEvents             = 11
+	sctpStatus             = 14
+	sctpGetPeerAddrInfo    = 15
+
+	sppHbEnable = 0x01
+
+	msgNotification              = 0x8000
+	msgEoR                       = syscall.MSG_EOR
+	sctpDataIO                   = 0x8000
+	sctpAssocChange              = 0x8001
+	sctpPeerAddrChange           = 0x8002
+	sctpSendFailed               = 0x8003
+	sctpRemoteError              = 0x8004
+	sctpShutdownEvent            = 0x8005
+	sctpPartialDeliveryEvent     = 0x8006
+	sctpAdaptationIndication     = 0x8007
+	sctpAuthenticationIndication = 0x8008
+	sctpSenderDryEvent           = 0x8009
+	sctpStreamResetEvent         = 0x800a
+	sctpAssocResetEvent          = 0x800b
+	sctpStreamChangeEvent        = 0x800c
+
+	sctpCommUp       = 0
+	sctpCommLost     = 1
+	sctpRestart      = 2
+	sctpShutdownComp = 3
+	sctpCantStrAssoc = 4
+
+	sctpStateClosed           = 1
+	sctpStateCookieWait       = 2
+	sctpStateCookieEchoed     = 3
+	sctpStateEstablished      = 4
+	sctpStateShutdownPending  = 5
+	sctpStateShutdownSent     = 6
+	sctpStateShutdownReceived = 7
+	sctpStateShutdownAckSent  = 8
+
+	sctpPathInactive    = 0
+	sctpPathPF          = 1
+	sctpPathActive      = 2
+	sctpPathUnconfirmed = 3
 )
 
-type assocT C.sctp_assoc_t
+type assocT int32
 
 func setNotify(fd int) error {
 	type opt struct {
@@ -72,27 +95,163 @@ func setNotify(fd int) error {
 		sendFailed:      0,
 		peerError:       0,
 		shutdown:        0,
-		partialDelivery: 0,
+		partialDelivery: 1,
 		adaptationLayer: 0,
 		authentication:  0,
-		senderDry:       0}
+		senderDry:       1}
 	l := unsafe.Sizeof(event)
 	p := unsafe.Pointer(&event)
 
-	return setSockOpt(fd, C.SCTP_EVENTS, p, l)
+	return setSockOpt(fd, sctpEvents, p, l)
+}
+
+func endOfRecord(flag int) bool {
+	return flag&msgEoR == msgEoR
+}
+
+func setRtoInfo(fd int, initial, max, min uint32) error {
+	type opt struct {
+		assocID assocT
+		initial uint32
+		max     uint32
+		min     uint32
+	}
+
+	rto := opt{
+		initial: initial,
+		max:     max,
+		min:     min}
+	return setSockOpt(fd, sctpRtoInfo, unsafe.Pointer(&rto), unsafe.Sizeof(rto))
+}
+
+func setPeerAddrParams(fd int, hbinterval uint32, pathmaxrxt uint16) error {
+	// struct sctp_paddrparams is packed
+	type opt struct {
+		assocID    assocT
+		address    [128]byte
+		hbinterval uint32
+		pathmaxrxt uint16
+		pathmtu    [4]byte
+		sackdelay  [4]byte
+		flags      [4]byte
+		flowlabel  [4]byte
+		dscp       uint8
+		_          uint8
+	}
+
+	param := opt{
+		hbinterval: hbinterval,
+		pathmaxrxt: pathmaxrxt}
+	if hbinterval != 0 {
+		binary.LittleEndian.PutUint32(param.flags[:], sppHbEnable)
+	}
+	return setSockOpt(fd, sctpPeerAddrParams, unsafe.Pointer(&param), unsafe.Sizeof(param))
+}
+
+func setInitMsg(fd int, ostreams, instreams, attempts, timeo uint16) error {
+	type opt struct {
+		ostreams  uint16
+		instreams uint16
+		attempts  uint16
+		timeo     uint16
+	}
+
+	msg := opt{
+		ostreams:  ostreams,
+		instreams: instreams,
+		attempts:  attempts,
+		timeo:     timeo}
+	return setSockOpt(fd, sctpInitMsg, unsafe.Pointer(&msg), unsafe.Sizeof(msg))
+}
+
+func setNoDelay(fd int) error {
+	opt := int32(1)
+	return setSockOpt(fd, sctpNoDelay, unsafe.Pointer(&opt), unsafe.Sizeof(opt))
 }
 
-func setSockOpt(fd, opt int, p unsafe.Pointer, l uintptr) error {
-	n, e := C.setsockopt(
-		C.int(fd),
-		C.SOL_SCTP,
-		C.int(opt),
-		p,
-		C.socklen_t(l))
-	if int(n) < 0 {
-		return e
+func setPrimaryAddr(fd int, id assocT, addr [128]byte) error {
+	// struct sctp_prim is packed
+	type opt struct {
+		assocID assocT
+		addr    [128]byte
+	}
+
+	prim := opt{assocID: id, addr: addr}
+	return setSockOpt(fd, sctpPrimaryAddr, unsafe.Pointer(&prim), unsafe.Sizeof(prim))
+}
+
+func setPeerPrimaryAddr(fd int, id assocT, addr [128]byte) error {
+	// struct sctp_setpeerprim is packed
+	type opt struct {
+		assocID assocT
+		addr    [128]byte
 	}
-	return nil
+
+	prim := opt{assocID: id, addr: addr}
+	return setSockOpt(fd, sctpSetPeerPrimaryAddr, unsafe.Pointer(&prim), unsafe.Sizeof(prim))
+}
+
+func setSockBuf(fd, opt, size int) error {
+	return syscall.SetsockoptInt(fd, syscall.SOL_SOCKET, opt, size)
+}
+
+// struct sctp_paddrinfo is packed
+type paddrinfo struct {
+	assocID assocT
+	address [128]byte
+	state   int32
+	cwnd    uint32
+	srtt    uint32
+	rto     uint32
+	mtu     uint32
+}
+
+func (p paddrinfo) pathInfo() PathInfo {
+	return PathInfo{
+		IP:    storageIP(p.address),
+		State: PathState(p.state),
+		Cwnd:  p.cwnd,
+		Srtt:  time.Duration(p.srtt) * time.Millisecond,
+		Rto:   time.Duration(p.rto) * time.Millisecond,
+		Mtu:   p.mtu}
+}
+
+func getStatus(fd int, id assocT) (Status, error) {
+	type opt struct {
+		assocID   assocT
+		state     int32
+		rwnd      uint32
+		unackdata uint16
+		penddata  uint16
+		instrms   uint16
+		outstrms  uint16
+		fragPoint uint32
+		primary   paddrinfo
+	}
+
+	stat := opt{assocID: id}
+	l := unsafe.Sizeof(stat)
+	if e := getSockOpt(fd, sctpStatus, unsafe.Pointer(&stat), &l); e != nil {
+		return Status{}, e
+	}
+	return Status{
+		State:              AssocState(stat.state),
+		Rwnd:               stat.rwnd,
+		UnackedData:        stat.unackdata,
+		PendingData:        stat.penddata,
+		InStreams:          stat.instrms,
+		OutStreams:         stat.outstrms,
+		FragmentationPoint: stat.fragPoint,
+		Primary:            stat.primary.pathInfo()}, nil
+}
+
+func getPathInfo(fd int, id assocT, addr [128]byte) (PathInfo, error) {
+	info := paddrinfo{assocID: id, address: addr}
+	l := unsafe.Sizeof(info)
+	if e := getSockOpt(fd, sctpGetPeerAddrInfo, unsafe.Pointer(&info), &l); e != nil {
+		return PathInfo{}, e
+	}
+	return info.pathInfo(), nil
 }
 
 func sockOpenV4() (int, error) {
@@ -112,60 +271,3 @@ func sockOpenV6() (int, error) {
 func sockClose(fd int) error {
 	return syscall.Close(fd)
 }
-
-func sctpBindx(fd int, ptr unsafe.Pointer, l int) error {
-	n, e := C.sctp_bindx(
-		C.int(fd),
-		(*C.struct_sockaddr)(ptr),
-		C.int(l),
-		C.SCTP_BINDX_ADD_ADDR)
-	if int(n) < 0 {
-		return e
-	}
-	return nil
-}
-
-func sctpConnectx(fd int, ptr unsafe.Pointer, l int) (assocT, error) {
-	t := assocT(0)
-	n, e := C.sctp_connectx(
-		C.int(fd),
-		(*C.struct_sockaddr)(ptr),
-		C.int(l),
-		(*C.sctp_assoc_t)(unsafe.Pointer(&t)))
-	if int(n) < 0 {
-		return t, e
-	}
-	return t, nil
-}
-
-func sctpSend(fd int, b []byte, info *sndrcvInfo, flag int) (int, error) {
-	buf := unsafe.Pointer(nil)
-	if len(b) > 0 {
-		buf = unsafe.Pointer(&b[0])
-	}
-	n, e := C.sctp_send(
-		C.int(fd),
-		buf,
-		C.size_t(len(b)),
-		(*C.struct_sctp_sndrcvinfo)(unsafe.Pointer(info)),
-		C.int(flag))
-	if int(n) < 0 {
-		return -1, e
-	}
-	return int(n), nil
-}
-
-func sctpRecvmsg(fd int, b []byte, info *sndrcvInfo, flag *int) (int, error) {
-	n, e := C.sctp_recvmsg(
-		C.int(fd),
-		unsafe.Pointer(&b[0]),
-		C.size_t(len(b)),
-		nil,
-		nil,
-		(*C.struct_sctp_sndrcvinfo)(unsafe.Pointer(info)),
-		(*C.int)(unsafe.Pointer(flag)))
-	if int(n) < 0 {
-		return -1, e
-	}
-	return int(n), nil
-}
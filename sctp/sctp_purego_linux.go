@@ -0,0 +1,135 @@
+//go:build purego && !386
+
+// Pure Go implementation without libsctp is selected by "purego" build tag.
+// linux/386 always uses libsctp because socket syscalls are multiplexed.
+
+package sctp
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// values are defined in <linux/sctp.h>
+const (
+	sctpSndrcv = 1
+
+	sctpSockoptBindxAdd = 100
+	sctpSockoptBindxRem = 101
+	sctpSockoptConnectx = 110
+)
+
+func setSockOpt(fd, opt int, p unsafe.Pointer, l uintptr) error {
+	_, _, e := syscall.Syscall6(
+		syscall.SYS_SETSOCKOPT,
+		uintptr(fd),
+		solSctp,
+		uintptr(opt),
+		uintptr(p),
+		l,
+		0)
+	if e != 0 {
+		return e
+	}
+	return nil
+}
+
+func getSockOpt(fd, opt int, p unsafe.Pointer, l *uintptr) error {
+	cl := uint32(*l)
+	_, _, e := syscall.Syscall6(
+		syscall.SYS_GETSOCKOPT,
+		uintptr(fd),
+		solSctp,
+		uintptr(opt),
+		uintptr(p),
+		uintptr(unsafe.Pointer(&cl)),
+		0)
+	*l = uintptr(cl)
+	if e != 0 {
+		return e
+	}
+	return nil
+}
+
+// addrsLen returns byte length of packed sockaddr array.
+func addrsLen(ptr unsafe.Pointer, l int) uintptr {
+	if (*syscall.RawSockaddr)(ptr).Family == syscall.AF_INET {
+		return uintptr(l) * syscall.SizeofSockaddrInet4
+	}
+	return uintptr(l) * syscall.SizeofSockaddrInet6
+}
+
+func sctpBindx(fd int, ptr unsafe.Pointer, l int, flag int) error {
+	opt := sctpSockoptBindxAdd
+	if flag == sctpBindxRemAddr {
+		opt = sctpSockoptBindxRem
+	}
+	return setSockOpt(fd, opt, ptr, addrsLen(ptr, l))
+}
+
+func sctpConnectx(fd int, ptr unsafe.Pointer, l int) (assocT, error) {
+	// SCTP_SOCKOPT_CONNECTX returns association ID
+	n, _, e := syscall.Syscall6(
+		syscall.SYS_SETSOCKOPT,
+		uintptr(fd),
+		solSctp,
+		sctpSockoptConnectx,
+		uintptr(ptr),
+		addrsLen(ptr, l),
+		0)
+	if e != 0 {
+		return 0, e
+	}
+	return assocT(n), nil
+}
+
+func sctpSend(fd int, b []byte, info *sndrcvInfo, flag int) (int, error) {
+	l := int(unsafe.Sizeof(*info))
+	oob := make([]byte, syscall.CmsgSpace(l))
+	h := (*syscall.Cmsghdr)(unsafe.Pointer(&oob[0]))
+	h.Level = solSctp
+	h.Type = sctpSndrcv
+	h.SetLen(syscall.CmsgLen(l))
+	*(*sndrcvInfo)(unsafe.Pointer(&oob[syscall.CmsgLen(0)])) = *info
+
+	msg := syscall.Msghdr{Control: &oob[0]}
+	msg.SetControllen(len(oob))
+	var iov syscall.Iovec
+	if len(b) > 0 {
+		iov.Base = &b[0]
+		iov.SetLen(len(b))
+		msg.Iov = &iov
+		msg.Iovlen = 1
+	}
+
+	n, _, e := syscall.Syscall(
+		syscall.SYS_SENDMSG,
+		uintptr(fd),
+		uintptr(unsafe.Pointer(&msg)),
+		uintptr(flag))
+	if e != 0 {
+		return -1, e
+	}
+	return int(n), nil
+}
+
+func sctpRecvmsg(fd int, b []byte, info *sndrcvInfo, flag *int) (int, error) {
+	oob := make([]byte, syscall.CmsgSpace(int(unsafe.Sizeof(*info))))
+	n, oobn, f, _, e := syscall.Recvmsg(fd, b, oob, 0)
+	if e != nil {
+		return -1, e
+	}
+	*flag = f
+
+	cmsgs, e := syscall.ParseSocketControlMessage(oob[:oobn])
+	if e != nil {
+		return -1, e
+	}
+	for _, c := range cmsgs {
+		if c.Header.Level == solSctp && c.Header.Type == sctpSndrcv &&
+			len(c.Data) >= int(unsafe.Sizeof(*info)) {
+			*info = *(*sndrcvInfo)(unsafe.Pointer(&c.Data[0]))
+		}
+	}
+	return n, nil
+}
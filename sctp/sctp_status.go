@@ -0,0 +1,142 @@
+package sctp
+
+import (
+	"net"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// AssocState is state of SCTP association.
+type AssocState int32
+
+// Association states
+const (
+	StateClosed           AssocState = sctpStateClosed
+	StateCookieWait       AssocState = sctpStateCookieWait
+	StateCookieEchoed     AssocState = sctpStateCookieEchoed
+	StateEstablished      AssocState = sctpStateEstablished
+	StateShutdownPending  AssocState = sctpStateShutdownPending
+	StateShutdownSent     AssocState = sctpStateShutdownSent
+	StateShutdownReceived AssocState = sctpStateShutdownReceived
+	StateShutdownAckSent  AssocState = sctpStateShutdownAckSent
+)
+
+func (s AssocState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateCookieWait:
+		return "cookie-wait"
+	case StateCookieEchoed:
+		return "cookie-echoed"
+	case StateEstablished:
+		return "established"
+	case StateShutdownPending:
+		return "shutdown-pending"
+	case StateShutdownSent:
+		return "shutdown-sent"
+	case StateShutdownReceived:
+		return "shutdown-received"
+	case StateShutdownAckSent:
+		return "shutdown-ack-sent"
+	}
+	return "unknown"
+}
+
+// PathState is state of destination transport address.
+type PathState int32
+
+// Path states
+const (
+	PathInactive    PathState = sctpPathInactive
+	PathPF          PathState = sctpPathPF // Potentially Failed
+	PathActive      PathState = sctpPathActive
+	PathUnconfirmed PathState = sctpPathUnconfirmed
+)
+
+func (s PathState) String() string {
+	switch s {
+	case PathInactive:
+		return "inactive"
+	case PathPF:
+		return "potentially failed"
+	case PathActive:
+		return "active"
+	case PathUnconfirmed:
+		return "unconfirmed"
+	}
+	return "unknown"
+}
+
+// Status is status of the association. (SCTP_STATUS)
+type Status struct {
+	State              AssocState
+	Rwnd               uint32
+	UnackedData        uint16
+	PendingData        uint16
+	InStreams          uint16
+	OutStreams         uint16
+	FragmentationPoint uint32
+	Primary            PathInfo
+}
+
+// PathInfo is status of a peer address. (SCTP_GET_PEER_ADDR_INFO)
+type PathInfo struct {
+	IP    net.IP
+	State PathState
+	Cwnd  uint32
+	Srtt  time.Duration
+	Rto   time.Duration
+	Mtu   uint32
+}
+
+// GetStatus returns status of the association.
+func GetStatus() (s Status, e error) {
+	if s, e = getStatus(sock, assocID); e != nil {
+		e = &net.OpError{
+			Op: "getsockopt", Net: "sctp",
+			Source: LocalAddr, Addr: PeerAddr, Err: e}
+	}
+	return
+}
+
+// GetPathInfo returns status of the peer address ip.
+func GetPathInfo(ip net.IP) (p PathInfo, e error) {
+	var addr [128]byte
+	if addr, e = PeerAddr.rawStorage(ip); e == nil {
+		p, e = getPathInfo(sock, assocID, addr)
+	}
+	if e != nil {
+		e = &net.OpError{
+			Op: "getsockopt", Net: "sctp",
+			Source: LocalAddr, Addr: PeerAddr, Err: e}
+	}
+	return
+}
+
+// GetPathsInfo returns status of all peer addresses.
+func GetPathsInfo() (p []PathInfo, e error) {
+	p = make([]PathInfo, len(PeerAddr.IP))
+	for i, ip := range PeerAddr.IP {
+		if p[i], e = GetPathInfo(ip); e != nil {
+			p = nil
+			return
+		}
+	}
+	return
+}
+
+func storageIP(s [128]byte) net.IP {
+	switch (*syscall.RawSockaddr)(unsafe.Pointer(&s[0])).Family {
+	case syscall.AF_INET:
+		a := (*syscall.RawSockaddrInet4)(unsafe.Pointer(&s[0]))
+		return net.IPv4(a.Addr[0], a.Addr[1], a.Addr[2], a.Addr[3])
+	case syscall.AF_INET6:
+		a := (*syscall.RawSockaddrInet6)(unsafe.Pointer(&s[0]))
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, a.Addr[:])
+		return ip
+	}
+	return nil
+}
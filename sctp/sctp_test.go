@@ -0,0 +1,28 @@
+package sctp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMsec(t *testing.T) {
+	for _, tc := range []struct {
+		d   time.Duration
+		max uint32
+		ms  uint32
+	}{
+		{0, 0xffffffff, 0},
+		{-time.Second, 0xffffffff, 0},
+		{time.Microsecond, 0xffffffff, 1},
+		{time.Millisecond, 0xffffffff, 1},
+		{time.Millisecond + 1, 0xffffffff, 2},
+		{time.Minute, 0xffffffff, 60000},
+		{time.Hour * 24 * 50, 0xffffffff, 0xffffffff},
+		{time.Second * 70, 0xffff, 0xffff},
+		{time.Second * 65, 0xffff, 65000},
+	} {
+		if ms := msec(tc.d, tc.max); ms != tc.ms {
+			t.Errorf("%v (max %d): %d, want %d", tc.d, tc.max, ms, tc.ms)
+		}
+	}
+}
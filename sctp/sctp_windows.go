@@ -3,13 +3,14 @@ package sctp
 import (
 	"log"
 	"syscall"
+	"time"
 	"unsafe"
 )
 
 const (
 	ipprotoSctp      = 0x84
 	sctpBindxAddAddr = 0x00008001
-	// sctpBindxRemAddr = 0x00008002
+	sctpBindxRemAddr = 0x00008002
 
 	sctpEoF       = 0x0100
 	sctpAbort     = 0x0200
@@ -18,9 +19,18 @@ const (
 	// SCTP_SENDALL = 0x1000
 	// SCTP_EOR = 0x2000
 	// SCTP_SACK_IMMEDIATELY = 0x4000
-	sctpEvents = 0x0000000c
+	sctpRtoInfo        = 0x00000001
+	sctpInitMsg        = 0x00000003
+	sctpNoDelay        = 0x00000004
+	sctpSetPeerPrimary = 0x00000006
+	sctpPrimaryAddr    = 0x00000007
+	sctpPeerAddrParams = 0x0000000a
+	sctpEvents         = 0x0000000c
+
+	sppHbEnable = 0x00000001
 
 	msgNotification          = 0x1000
+	msgPartial               = 0x8000
 	sctpAssocChange          = 0x0001
 	sctpPeerAddrChange       = 0x0002
 	sctpRemoteError          = 0x0003
@@ -35,6 +45,23 @@ const (
 	sctpRestart      = 0x0003
 	sctpShutdownComp = 0x0004
 	sctpCantStrAssoc = 0x0005
+
+	sctpStatus         = 0x00000100
+	sctpGetPeerAddInfo = 0x00000101
+
+	sctpStateClosed           = 0x0000
+	sctpStateCookieWait       = 0x0002
+	sctpStateCookieEchoed     = 0x0004
+	sctpStateEstablished      = 0x0008
+	sctpStateShutdownSent     = 0x0010
+	sctpStateShutdownReceived = 0x0020
+	sctpStateShutdownAckSent  = 0x0040
+	sctpStateShutdownPending  = 0x0080
+
+	sctpPathActive      = 0x0001
+	sctpPathInactive    = 0x0002
+	sctpPathUnconfirmed = 0x0200
+	sctpPathPF          = 0x0800
 )
 
 type assocT uint32
@@ -100,10 +127,10 @@ func setNotify(fd int) error {
 		sendFailure:     0,
 		peerError:       0,
 		shutdown:        0,
-		partialDelivery: 0,
+		partialDelivery: 1,
 		adaptationLayer: 0,
 		authentication:  0,
-		senderDry:       0,
+		senderDry:       1,
 		streamReset:     0}
 	l := unsafe.Sizeof(event)
 	p := unsafe.Pointer(&event)
@@ -111,6 +138,10 @@ func setNotify(fd int) error {
 	return setSockOpt(fd, sctpEvents, p, l)
 }
 
+func endOfRecord(flag int) bool {
+	return flag&msgPartial != msgPartial
+}
+
 func setSockOpt(fd, opt int, p unsafe.Pointer, l uintptr) error {
 	return syscall.Setsockopt(
 		syscall.Handle(fd),
@@ -120,6 +151,159 @@ func setSockOpt(fd, opt int, p unsafe.Pointer, l uintptr) error {
 		int32(l))
 }
 
+func setRtoInfo(fd int, initial, max, min uint32) error {
+	type opt struct {
+		assocID assocT
+		initial uint32
+		max     uint32
+		min     uint32
+	}
+
+	rto := opt{
+		initial: initial,
+		max:     max,
+		min:     min}
+	return setSockOpt(fd, sctpRtoInfo, unsafe.Pointer(&rto), unsafe.Sizeof(rto))
+}
+
+func setPeerAddrParams(fd int, hbinterval uint32, pathmaxrxt uint16) error {
+	type opt struct {
+		address    [128]byte
+		assocID    assocT
+		hbinterval uint32
+		pathmtu    uint32
+		flags      uint32
+		flowlabel  uint32
+		pathmaxrxt uint16
+		tos        uint8
+	}
+
+	param := opt{
+		hbinterval: hbinterval,
+		pathmaxrxt: pathmaxrxt}
+	if hbinterval != 0 {
+		param.flags = sppHbEnable
+	}
+	return setSockOpt(fd, sctpPeerAddrParams, unsafe.Pointer(&param), unsafe.Sizeof(param))
+}
+
+func setInitMsg(fd int, ostreams, instreams, attempts, timeo uint16) error {
+	type opt struct {
+		ostreams  uint16
+		instreams uint16
+		attempts  uint16
+		timeo     uint16
+	}
+
+	msg := opt{
+		ostreams:  ostreams,
+		instreams: instreams,
+		attempts:  attempts,
+		timeo:     timeo}
+	return setSockOpt(fd, sctpInitMsg, unsafe.Pointer(&msg), unsafe.Sizeof(msg))
+}
+
+func setNoDelay(fd int) error {
+	opt := int32(1)
+	return setSockOpt(fd, sctpNoDelay, unsafe.Pointer(&opt), unsafe.Sizeof(opt))
+}
+
+func setPrimaryAddr(fd int, id assocT, addr [128]byte) error {
+	type opt struct {
+		addr    [128]byte
+		assocID assocT
+		_       [4]byte
+	}
+
+	prim := opt{assocID: id, addr: addr}
+	return setSockOpt(fd, sctpPrimaryAddr, unsafe.Pointer(&prim), unsafe.Sizeof(prim))
+}
+
+func setPeerPrimaryAddr(fd int, id assocT, addr [128]byte) error {
+	type opt struct {
+		addr    [128]byte
+		assocID assocT
+		_       [4]byte
+	}
+
+	prim := opt{assocID: id, addr: addr}
+	return setSockOpt(fd, sctpSetPeerPrimary, unsafe.Pointer(&prim), unsafe.Sizeof(prim))
+}
+
+func setSockBuf(fd, opt, size int) error {
+	return syscall.SetsockoptInt(syscall.Handle(fd), syscall.SOL_SOCKET, opt, size)
+}
+
+type paddrinfo struct {
+	address [128]byte
+	assocID assocT
+	state   int32
+	cwnd    uint32
+	srtt    uint32
+	rto     uint32
+	mtu     uint32
+}
+
+func (p paddrinfo) pathInfo() PathInfo {
+	return PathInfo{
+		IP:    storageIP(p.address),
+		State: PathState(p.state),
+		Cwnd:  p.cwnd,
+		Srtt:  time.Duration(p.srtt) * time.Millisecond,
+		Rto:   time.Duration(p.rto) * time.Millisecond,
+		Mtu:   p.mtu}
+}
+
+func getStatus(fd int, id assocT) (Status, error) {
+	type opt struct {
+		assocID   assocT
+		state     int32
+		rwnd      uint32
+		unackdata uint16
+		penddata  uint16
+		instrms   uint16
+		outstrms  uint16
+		fragPoint uint32
+		primary   paddrinfo
+	}
+
+	stat := opt{assocID: id}
+	l := unsafe.Sizeof(stat)
+	if e := getSockOpt(fd, sctpStatus, unsafe.Pointer(&stat), &l); e != nil {
+		return Status{}, e
+	}
+	return Status{
+		State:              AssocState(stat.state),
+		Rwnd:               stat.rwnd,
+		UnackedData:        stat.unackdata,
+		PendingData:        stat.penddata,
+		InStreams:          stat.instrms,
+		OutStreams:         stat.outstrms,
+		FragmentationPoint: stat.fragPoint,
+		Primary:            stat.primary.pathInfo()}, nil
+}
+
+func getPathInfo(fd int, id assocT, addr [128]byte) (PathInfo, error) {
+	info := paddrinfo{assocID: id, address: addr}
+	l := unsafe.Sizeof(info)
+	if e := getSockOpt(fd, sctpGetPeerAddInfo, unsafe.Pointer(&info), &l); e != nil {
+		return PathInfo{}, e
+	}
+	return info.pathInfo(), nil
+}
+
+func getSockOpt(fd, opt int, p unsafe.Pointer, l *uintptr) error {
+	cl := int32(*l)
+	e := syscall.Getsockopt(
+		syscall.Handle(fd),
+		ipprotoSctp,
+		int32(opt),
+		(*byte)(p),
+		&cl)
+	*l = uintptr(cl)
+	return e
+}
+
 func sockOpenV4() (int, error) {
 	sock, e := syscall.Socket(
 		syscall.AF_INET,
@@ -145,12 +329,12 @@ func sockClose(fd int) error {
 	return e2
 }
 
-func sctpBindx(fd int, ptr unsafe.Pointer, l int) error {
+func sctpBindx(fd int, ptr unsafe.Pointer, l int, flag int) error {
 	n, _, e := fsctpBindx.Call(
 		uintptr(fd),
 		uintptr(ptr),
 		uintptr(l),
-		sctpBindxAddAddr)
+		uintptr(flag))
 	if int(n) < 0 {
 		return e
 	}
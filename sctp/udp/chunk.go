@@ -0,0 +1,372 @@
+package udp
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"net"
+)
+
+/*
+SCTP common header
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|     Source Port Number        |     Destination Port Number   |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Verification Tag                         |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                           Checksum                            |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+
+const (
+	ctData             = 0
+	ctInit             = 1
+	ctInitAck          = 2
+	ctSack             = 3
+	ctHeartbeat        = 4
+	ctHeartbeatAck     = 5
+	ctAbort            = 6
+	ctShutdown         = 7
+	ctShutdownAck      = 8
+	ctError            = 9
+	ctCookieEcho       = 10
+	ctCookieAck        = 11
+	ctShutdownComplete = 14
+
+	// T bit of ABORT and SHUTDOWN COMPLETE
+	flagT = 0x01
+
+	// flags of DATA
+	flagE = 0x01
+	flagB = 0x02
+	flagU = 0x04
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+type chunk struct {
+	typ   uint8
+	flags uint8
+	value []byte
+}
+
+func (c chunk) bytes() []byte {
+	l := 4 + len(c.value)
+	b := make([]byte, l+pad(l))
+	b[0] = c.typ
+	b[1] = c.flags
+	binary.BigEndian.PutUint16(b[2:], uint16(l))
+	copy(b[4:], c.value)
+	return b
+}
+
+func pad(l int) int {
+	if l%4 != 0 {
+		return 4 - l%4
+	}
+	return 0
+}
+
+func buildPacket(src, dst uint16, vtag uint32, chunks ...[]byte) []byte {
+	l := 12
+	for _, c := range chunks {
+		l += len(c)
+	}
+	b := make([]byte, 12, l)
+	binary.BigEndian.PutUint16(b[0:], src)
+	binary.BigEndian.PutUint16(b[2:], dst)
+	binary.BigEndian.PutUint32(b[4:], vtag)
+	for _, c := range chunks {
+		b = append(b, c...)
+	}
+	binary.LittleEndian.PutUint32(b[8:], crc32.Checksum(b, castagnoli))
+	return b
+}
+
+func parsePacket(b []byte) (src, dst uint16, vtag uint32, chunks []chunk, e error) {
+	if len(b) < 16 {
+		e = errors.New("too short packet")
+		return
+	}
+	sum := binary.LittleEndian.Uint32(b[8:])
+	binary.LittleEndian.PutUint32(b[8:], 0)
+	if crc32.Checksum(b, castagnoli) != sum {
+		e = errors.New("invalid checksum")
+		return
+	}
+	src = binary.BigEndian.Uint16(b[0:])
+	dst = binary.BigEndian.Uint16(b[2:])
+	vtag = binary.BigEndian.Uint32(b[4:])
+
+	for b = b[12:]; len(b) >= 4; {
+		l := int(binary.BigEndian.Uint16(b[2:]))
+		if l < 4 || l > len(b) {
+			e = errors.New("invalid chunk length")
+			return
+		}
+		chunks = append(chunks, chunk{
+			typ:   b[0],
+			flags: b[1],
+			value: b[4:l]})
+		if l += pad(l); l > len(b) {
+			break
+		}
+		b = b[l:]
+	}
+	return
+}
+
+func parameter(t uint16, v []byte) []byte {
+	l := 4 + len(v)
+	b := make([]byte, l+pad(l))
+	binary.BigEndian.PutUint16(b[0:], t)
+	binary.BigEndian.PutUint16(b[2:], uint16(l))
+	copy(b[4:], v)
+	return b
+}
+
+func parseParameters(b []byte, f func(uint16, []byte)) error {
+	for len(b) >= 4 {
+		t := binary.BigEndian.Uint16(b[0:])
+		l := int(binary.BigEndian.Uint16(b[2:]))
+		if l < 4 || l > len(b) {
+			return errors.New("invalid parameter length")
+		}
+		f(t, b[4:l])
+		if l += pad(l); l > len(b) {
+			break
+		}
+		b = b[l:]
+	}
+	return nil
+}
+
+/*
+INIT and INIT ACK chunk
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|   Type = 1/2  |  Chunk Flags  |      Chunk Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                         Initiate Tag                          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|           Advertised Receiver Window Credit (a_rwnd)          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|  Number of Outbound Streams   |  Number of Inbound Streams    |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                          Initial TSN                          |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	\                                                               \
+	/              Optional/Variable-Length Parameters              /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type initChunk struct {
+	tag     uint32
+	rwnd    uint32
+	os      uint16
+	mis     uint16
+	tsn     uint32
+	addrs   []net.IP
+	cookie  []byte
+	typeAck bool
+}
+
+func (c initChunk) bytes() []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint32(b[0:], c.tag)
+	binary.BigEndian.PutUint32(b[4:], c.rwnd)
+	binary.BigEndian.PutUint16(b[8:], c.os)
+	binary.BigEndian.PutUint16(b[10:], c.mis)
+	binary.BigEndian.PutUint32(b[12:], c.tsn)
+	for _, a := range c.addrs {
+		if a4 := a.To4(); a4 != nil {
+			// IPv4 Address
+			b = append(b, parameter(5, a4)...)
+		} else if a16 := a.To16(); a16 != nil {
+			// IPv6 Address
+			b = append(b, parameter(6, a16)...)
+		}
+	}
+	if c.cookie != nil {
+		// State Cookie
+		b = append(b, parameter(7, c.cookie)...)
+	}
+
+	ch := chunk{typ: ctInit, value: b}
+	if c.typeAck {
+		ch.typ = ctInitAck
+	}
+	return ch.bytes()
+}
+
+func parseInit(v []byte) (c initChunk, e error) {
+	if len(v) < 16 {
+		e = errors.New("too short INIT")
+		return
+	}
+	c.tag = binary.BigEndian.Uint32(v[0:])
+	c.rwnd = binary.BigEndian.Uint32(v[4:])
+	c.os = binary.BigEndian.Uint16(v[8:])
+	c.mis = binary.BigEndian.Uint16(v[10:])
+	c.tsn = binary.BigEndian.Uint32(v[12:])
+	if c.tag == 0 || c.os == 0 || c.mis == 0 {
+		e = errors.New("invalid mandatory parameter")
+		return
+	}
+	e = parseParameters(v[16:], func(t uint16, p []byte) {
+		switch t {
+		case 5:
+			if len(p) == net.IPv4len {
+				c.addrs = append(c.addrs, net.IP(append([]byte{}, p...)))
+			}
+		case 6:
+			if len(p) == net.IPv6len {
+				c.addrs = append(c.addrs, net.IP(append([]byte{}, p...)))
+			}
+		case 7:
+			c.cookie = append([]byte{}, p...)
+		}
+	})
+	return
+}
+
+/*
+DATA chunk
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|   Type = 0    | Reserved|U|B|E|    Length                     |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                              TSN                              |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|      Stream Identifier S      |   Stream Sequence Number n    |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                  Payload Protocol Identifier                  |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	\                                                               \
+	/                 User Data (seq n of Stream S)                 /
+	\                                                               \
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+*/
+type dataChunk struct {
+	flags  uint8
+	tsn    uint32
+	stream uint16
+	ssn    uint16
+	ppid   uint32
+	data   []byte
+}
+
+func (c *dataChunk) bytes() []byte {
+	b := make([]byte, 12+len(c.data))
+	binary.BigEndian.PutUint32(b[0:], c.tsn)
+	binary.BigEndian.PutUint16(b[4:], c.stream)
+	binary.BigEndian.PutUint16(b[6:], c.ssn)
+	binary.BigEndian.PutUint32(b[8:], c.ppid)
+	copy(b[12:], c.data)
+	return chunk{typ: ctData, flags: c.flags, value: b}.bytes()
+}
+
+func parseData(flags uint8, v []byte) (c *dataChunk, e error) {
+	if len(v) <= 12 {
+		e = errors.New("no user data")
+		return
+	}
+	c = &dataChunk{
+		flags:  flags,
+		tsn:    binary.BigEndian.Uint32(v[0:]),
+		stream: binary.BigEndian.Uint16(v[4:]),
+		ssn:    binary.BigEndian.Uint16(v[6:]),
+		ppid:   binary.BigEndian.Uint32(v[8:]),
+		data:   append([]byte{}, v[12:]...)}
+	return
+}
+
+/*
+SACK chunk
+
+	 0                   1                   2                   3
+	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|   Type = 3    |Chunk  Flags   |      Chunk Length             |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|                      Cumulative TSN Ack                       |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|          Advertised Receiver Window Credit (a_rwnd)           |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	| Number of Gap Ack Blocks = N  |  Number of Duplicate TSNs = X |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	|  Gap Ack Block #1 Start       |   Gap Ack Block #1 End        |
+	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	/                                                               /
+*/
+type sackChunk struct {
+	cumTSN uint32
+	rwnd   uint32
+	gaps   [][2]uint16
+}
+
+func (c sackChunk) bytes() []byte {
+	b := make([]byte, 12+4*len(c.gaps))
+	binary.BigEndian.PutUint32(b[0:], c.cumTSN)
+	binary.BigEndian.PutUint32(b[4:], c.rwnd)
+	binary.BigEndian.PutUint16(b[8:], uint16(len(c.gaps)))
+	for i, g := range c.gaps {
+		binary.BigEndian.PutUint16(b[12+4*i:], g[0])
+		binary.BigEndian.PutUint16(b[14+4*i:], g[1])
+	}
+	return chunk{typ: ctSack, value: b}.bytes()
+}
+
+func parseSack(v []byte) (c sackChunk, e error) {
+	if len(v) < 12 {
+		e = errors.New("too short SACK")
+		return
+	}
+	c.cumTSN = binary.BigEndian.Uint32(v[0:])
+	c.rwnd = binary.BigEndian.Uint32(v[4:])
+	n := int(binary.BigEndian.Uint16(v[8:]))
+	if len(v) < 12+4*n {
+		e = errors.New("too short SACK")
+		return
+	}
+	c.gaps = make([][2]uint16, n)
+	for i := range c.gaps {
+		c.gaps[i][0] = binary.BigEndian.Uint16(v[12+4*i:])
+		c.gaps[i][1] = binary.BigEndian.Uint16(v[14+4*i:])
+	}
+	return
+}
+
+func cumTSNChunk(typ uint8, tsn uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, tsn)
+	return chunk{typ: typ, value: b}.bytes()
+}
+
+// abortChunk returns ABORT chunk with User-Initiated Abort cause.
+func abortChunk(reason string, t bool) []byte {
+	ch := chunk{typ: ctAbort}
+	if t {
+		ch.flags = flagT
+	}
+	if len(reason) != 0 {
+		ch.value = parameter(12, []byte(reason))
+	}
+	return ch.bytes()
+}
+
+// tsnLT compares TSN with serial number arithmetic.
+func tsnLT(a, b uint32) bool {
+	return int32(a-b) < 0
+}
+
+func tsnLTE(a, b uint32) bool {
+	return a == b || tsnLT(a, b)
+}
@@ -0,0 +1,1225 @@
+/*
+Package udp implements user space SCTP association over UDP encapsulation (RFC 6951).
+It is used as Transport of xua where SCTP of kernel is not available.
+*/
+package udp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fkgi/xua/sctp"
+)
+
+// Port is default UDP port for SCTP encapsulation.
+const Port = 9899
+
+const (
+	mtu       = 1200
+	rwnd      = 1 << 20 // receive buffer size
+	initCwnd  = 4380    // min(4*MTU, max(2*MTU, 4380))
+	cookieAge = time.Minute
+	tick      = 10 * time.Millisecond
+)
+
+// Transport is SCTP association over UDP.
+// Multiple IP addresses of LocalAddr and PeerAddr are used for multihoming.
+// If PeerAddr is nil, Serve waits INIT from any peer.
+type Transport struct {
+	LocalAddr *sctp.SCTPAddr
+	PeerAddr  *sctp.SCTPAddr
+
+	// UDP encapsulation port. Zero means Port.
+	LocalPort int
+	PeerPort  int
+
+	// PPID is payload protocol ID of sending data. Zero means SUA (4).
+	PPID uint32
+
+	// Zero value of following parameters means default value.
+	OutStreams        uint16        // default 16
+	MaxInStreams      uint16        // default 16
+	RtoInitial        time.Duration // default 3s
+	RtoMin            time.Duration // default 1s
+	RtoMax            time.Duration // default 60s
+	HeartbeatInterval time.Duration // default 30s
+	PathMaxRetrans    int           // default 5
+	AssocMaxRetrans   int           // default 10
+	MaxInitAttempts   int           // default 8
+
+	mutex sync.Mutex
+	a     *assoc
+}
+
+const (
+	stateClosed = iota
+	stateListen
+	stateCookieWait
+	stateCookieEchoed
+	stateEstablished
+	stateShutdownPending
+	stateShutdownSent
+	stateShutdownReceived
+	stateShutdownAckSent
+)
+
+type packet struct {
+	b    []byte
+	from *net.UDPAddr
+	conn *net.UDPConn
+}
+
+type path struct {
+	addr *net.UDPAddr
+	conn *net.UDPConn
+
+	active bool
+	errors int
+	srtt   time.Duration
+	rttvar time.Duration
+	rto    time.Duration
+
+	hbTime    time.Time
+	hbPending bool
+
+	// congestion control (RFC 4960 7.2)
+	cwnd     int
+	ssthresh int
+	pba      int
+}
+
+type outData struct {
+	*dataChunk
+	sent   time.Time
+	retx   bool
+	acked  bool
+	length int
+}
+
+type assoc struct {
+	t     *Transport
+	conns []*net.UDPConn
+	rx    chan packet
+	cmd   chan func()
+	done  chan struct{}
+
+	handleData func([]byte, uint16, uint32)
+	handleUp   func()
+	handleDown func()
+
+	// received messages for handleData
+	inbox   []*dataChunk
+	inLen   int
+	inMutex sync.Mutex
+	inReady chan struct{}
+
+	mutex      sync.Mutex
+	state      int
+	outStreams uint16
+	inStreams  uint16
+
+	up     bool
+	secret []byte
+
+	localTag uint32
+	peerTag  uint32
+	initTSN  uint32
+	peerPort uint16
+	paths    []*path
+	primary  int
+
+	// T1-init, T1-cookie and T2-shutdown
+	ctrl      []byte
+	ctrlTime  time.Time
+	ctrlCount int
+
+	// sender
+	nextTSN  uint32
+	ssn      []uint16
+	peerRwnd uint32
+	queue    []*outData
+	sent     []*outData
+	t3       time.Time
+	errCount int
+	dry      []chan struct{}
+
+	// receiver
+	cumTSN   uint32
+	pending  map[uint32]*dataChunk
+	pendLen  int
+	reasm    []*dataChunk
+	reasmLen int
+	discard  bool
+}
+
+func (t *Transport) param(v, d int) int {
+	if v == 0 {
+		return d
+	}
+	return v
+}
+
+func (t *Transport) duration(v, d time.Duration) time.Duration {
+	if v == 0 {
+		return d
+	}
+	return v
+}
+
+// Serve starts association and handles received data.
+// It returns when the association is closed.
+func (t *Transport) Serve(handleData func([]byte, uint16, uint32), handleUp, handleDown func()) (e error) {
+	a := &assoc{
+		t:          t,
+		rx:         make(chan packet, 1024),
+		cmd:        make(chan func(), 1024),
+		done:       make(chan struct{}),
+		inReady:    make(chan struct{}, 1),
+		handleData: handleData,
+		handleUp:   handleUp,
+		handleDown: handleDown,
+		pending:    make(map[uint32]*dataChunk)}
+
+	t.mutex.Lock()
+	if t.a != nil {
+		t.mutex.Unlock()
+		return errors.New("transport is already served")
+	}
+	t.a = a
+	t.mutex.Unlock()
+	defer func() {
+		t.mutex.Lock()
+		t.a = nil
+		t.mutex.Unlock()
+	}()
+
+	if e = a.open(); e != nil {
+		return
+	}
+	defer func() {
+		for _, c := range a.conns {
+			c.Close()
+		}
+	}()
+
+	rnd := make([]byte, 40)
+	if _, e = rand.Read(rnd); e != nil {
+		return
+	}
+	a.secret = rnd[8:]
+	a.localTag = binary.BigEndian.Uint32(rnd[0:]) | 1
+	a.initTSN = binary.BigEndian.Uint32(rnd[4:])
+	a.nextTSN = a.initTSN
+
+	for _, c := range a.conns {
+		go a.read(c)
+	}
+	go a.dispatch()
+
+	if t.PeerAddr != nil {
+		a.peerPort = uint16(t.PeerAddr.Port)
+		port := t.param(t.PeerPort, Port)
+		for _, ip := range t.PeerAddr.IP {
+			a.addPath(&net.UDPAddr{IP: ip, Port: port})
+		}
+		a.setState(stateCookieWait)
+		a.sendCtrl(a.initChunk(false, nil), 0)
+	} else {
+		a.setState(stateListen)
+	}
+
+	e = a.run()
+	close(a.done)
+	return
+}
+
+func (a *assoc) open() error {
+	port := a.t.param(a.t.LocalPort, Port)
+	if a.t.LocalAddr == nil || len(a.t.LocalAddr.IP) == 0 {
+		c, e := net.ListenUDP("udp", &net.UDPAddr{Port: port})
+		if e != nil {
+			return e
+		}
+		a.conns = append(a.conns, c)
+		return nil
+	}
+	for _, ip := range a.t.LocalAddr.IP {
+		c, e := net.ListenUDP("udp", &net.UDPAddr{IP: ip, Port: port})
+		if e != nil {
+			for _, c := range a.conns {
+				c.Close()
+			}
+			return e
+		}
+		a.conns = append(a.conns, c)
+	}
+	return nil
+}
+
+func (a *assoc) read(c *net.UDPConn) {
+	buf := make([]byte, 65536)
+	for {
+		n, from, e := c.ReadFromUDP(buf)
+		if e != nil {
+			return
+		}
+		b := make([]byte, n)
+		copy(b, buf)
+		select {
+		case a.rx <- packet{b: b, from: from, conn: c}:
+		case <-a.done:
+			return
+		}
+	}
+}
+
+func (a *assoc) setState(s int) {
+	a.mutex.Lock()
+	a.state = s
+	a.mutex.Unlock()
+}
+
+func (a *assoc) run() error {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for a.state != stateClosed {
+		select {
+		case p := <-a.rx:
+			a.receive(p)
+		case f := <-a.cmd:
+			f()
+		case now := <-ticker.C:
+			a.timer(now)
+		}
+	}
+	if !a.up {
+		return errors.New("failed to establish association")
+	}
+	return nil
+}
+
+// call runs f in the association goroutine.
+func (a *assoc) call(f func() error) error {
+	r := make(chan error, 1)
+	select {
+	case a.cmd <- func() { r <- f() }:
+	case <-a.done:
+		return errors.New("association is closed")
+	}
+	select {
+	case e := <-r:
+		return e
+	case <-a.done:
+		return errors.New("association is closed")
+	}
+}
+
+func (t *Transport) current() (*assoc, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.a == nil {
+		return nil, errors.New("transport is not served")
+	}
+	return t.a, nil
+}
+
+// Write sends data on stream s.
+func (t *Transport) Write(b []byte, s uint16) error {
+	a, e := t.current()
+	if e != nil {
+		return e
+	}
+	data := make([]byte, len(b))
+	copy(data, b)
+	return a.call(func() error {
+		if a.state != stateEstablished {
+			return errors.New("association is not established")
+		}
+		if int(s) >= len(a.ssn) {
+			return errors.New("invalid stream")
+		}
+		a.enqueue(data, s)
+		a.flush()
+		return nil
+	})
+}
+
+// Streams returns number of outbound and inbound streams.
+func (t *Transport) Streams() (out, in uint16) {
+	a, e := t.current()
+	if e != nil {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.outStreams, a.inStreams
+}
+
+// Flush waits until all sent data is acknowledged by the peer,
+// the association is closed or ctx is done.
+func (t *Transport) Flush(ctx context.Context) error {
+	a, e := t.current()
+	if e != nil {
+		return e
+	}
+	c := make(chan struct{})
+	if e = a.call(func() error {
+		if len(a.sent) == 0 && len(a.queue) == 0 {
+			close(c)
+		} else {
+			a.dry = append(a.dry, c)
+		}
+		return nil
+	}); e != nil {
+		return e
+	}
+	select {
+	case <-c:
+	case <-a.done:
+	case <-ctx.Done():
+		a.call(func() error {
+			for i, w := range a.dry {
+				if w == c {
+					a.dry = append(a.dry[:i], a.dry[i+1:]...)
+					break
+				}
+			}
+			return nil
+		})
+		return ctx.Err()
+	}
+	return nil
+}
+
+// Close shutdowns the association gracefully.
+func (t *Transport) Close() error {
+	a, e := t.current()
+	if e != nil {
+		return e
+	}
+	return a.call(func() error {
+		switch a.state {
+		case stateEstablished:
+			a.state = stateShutdownPending
+			a.shutdown()
+		case stateListen, stateCookieWait, stateCookieEchoed:
+			a.abort("")
+		}
+		return nil
+	})
+}
+
+// Abort closes the association with abort message.
+func (t *Transport) Abort(reason string) error {
+	a, e := t.current()
+	if e != nil {
+		return e
+	}
+	return a.call(func() error {
+		a.abort(reason)
+		return nil
+	})
+}
+
+func (a *assoc) addPath(addr *net.UDPAddr) *path {
+	for _, p := range a.paths {
+		if p.addr.IP.Equal(addr.IP) {
+			return p
+		}
+	}
+	p := &path{
+		addr:     addr,
+		active:   true,
+		rto:      a.t.duration(a.t.RtoInitial, 3*time.Second),
+		cwnd:     initCwnd,
+		ssthresh: rwnd}
+	if a.peerRwnd != 0 {
+		p.ssthresh = int(a.peerRwnd)
+	}
+	for _, c := range a.conns {
+		l := c.LocalAddr().(*net.UDPAddr)
+		if (l.IP.To4() != nil) == (addr.IP.To4() != nil) || l.IP.IsUnspecified() {
+			p.conn = c
+			break
+		}
+	}
+	if p.conn == nil {
+		p.conn = a.conns[0]
+	}
+	a.paths = append(a.paths, p)
+	return p
+}
+
+func (a *assoc) findPath(addr *net.UDPAddr) (int, *path) {
+	for i, p := range a.paths {
+		if p.addr.IP.Equal(addr.IP) {
+			return i, p
+		}
+	}
+	return -1, nil
+}
+
+// current path to send
+func (a *assoc) path() *path {
+	if a.paths[a.primary].active {
+		return a.paths[a.primary]
+	}
+	for _, p := range a.paths {
+		if p.active {
+			return p
+		}
+	}
+	return a.paths[a.primary]
+}
+
+func (a *assoc) send(p *path, vtag uint32, chunks ...[]byte) {
+	a.sendTo(p, a.peerPort, vtag, chunks...)
+}
+
+// sendTo sends chunks to SCTP port dst of the peer.
+func (a *assoc) sendTo(p *path, dst uint16, vtag uint32, chunks ...[]byte) {
+	p.conn.WriteToUDP(buildPacket(a.port(), dst, vtag, chunks...), p.addr)
+}
+
+// port returns local SCTP port.
+func (a *assoc) port() uint16 {
+	if a.t.LocalAddr != nil {
+		return uint16(a.t.LocalAddr.Port)
+	}
+	return 0
+}
+
+// sendCtrl sends control chunk with retransmission timer.
+func (a *assoc) sendCtrl(c []byte, vtag uint32) {
+	a.ctrl = c
+	a.ctrlCount = 0
+	p := a.path()
+	a.ctrlTime = time.Now().Add(p.rto)
+	a.send(p, vtag, c)
+}
+
+func (a *assoc) initChunk(ack bool, cookie []byte) []byte {
+	c := initChunk{
+		tag:     a.localTag,
+		rwnd:    rwnd,
+		os:      uint16(a.t.param(int(a.t.OutStreams), 16)),
+		mis:     uint16(a.t.param(int(a.t.MaxInStreams), 16)),
+		tsn:     a.initTSN,
+		cookie:  cookie,
+		typeAck: ack}
+	if a.t.LocalAddr != nil {
+		c.addrs = a.t.LocalAddr.IP
+	}
+	return c.bytes()
+}
+
+/*
+State Cookie
+
+	local tag, peer tag, local TSN, peer TSN, peer rwnd,
+	outbound streams, inbound streams, timestamp and HMAC-SHA256
+*/
+func (a *assoc) cookie(peer initChunk) []byte {
+	b := make([]byte, 32, 64)
+	binary.BigEndian.PutUint32(b[0:], a.localTag)
+	binary.BigEndian.PutUint32(b[4:], peer.tag)
+	binary.BigEndian.PutUint32(b[8:], a.initTSN)
+	binary.BigEndian.PutUint32(b[12:], peer.tsn)
+	binary.BigEndian.PutUint32(b[16:], peer.rwnd)
+	binary.BigEndian.PutUint16(b[20:], min16(uint16(a.t.param(int(a.t.OutStreams), 16)), peer.mis))
+	binary.BigEndian.PutUint16(b[22:], min16(uint16(a.t.param(int(a.t.MaxInStreams), 16)), peer.os))
+	binary.BigEndian.PutUint64(b[24:], uint64(time.Now().UnixNano()))
+	h := hmac.New(sha256.New, a.secret)
+	h.Write(b)
+	return h.Sum(b)
+}
+
+func min16(a, b uint16) uint16 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (a *assoc) establish(peerTag, peerTSN, peerRwnd uint32, out, in uint16) {
+	a.peerTag = peerTag
+	a.cumTSN = peerTSN - 1
+	a.peerRwnd = peerRwnd
+	a.ssn = make([]uint16, out)
+	for _, p := range a.paths {
+		p.cwnd, p.ssthresh, p.pba = initCwnd, int(peerRwnd), 0
+	}
+	a.mutex.Lock()
+	a.outStreams, a.inStreams = out, in
+	a.state = stateEstablished
+	a.mutex.Unlock()
+	a.ctrl = nil
+
+	now := time.Now()
+	for _, p := range a.paths {
+		p.hbTime = now
+	}
+	if !a.up {
+		a.up = true
+		go a.handleUp()
+	}
+}
+
+func (a *assoc) closed() {
+	a.setState(stateClosed)
+	if a.up {
+		go a.handleDown()
+	}
+}
+
+func (a *assoc) abort(reason string) {
+	if a.state != stateListen && len(a.paths) != 0 {
+		if a.peerTag != 0 {
+			a.send(a.path(), a.peerTag, abortChunk(reason, false))
+		} else {
+			a.send(a.path(), a.localTag, abortChunk(reason, true))
+		}
+	}
+	a.closed()
+}
+
+func (a *assoc) receive(p packet) {
+	src, dst, vtag, chunks, e := parsePacket(p.b)
+	if e != nil || len(chunks) == 0 || dst != a.port() {
+		return
+	}
+	if a.t.PeerAddr != nil && int(src) != a.t.PeerAddr.Port {
+		return
+	}
+
+	if chunks[0].typ == ctInit {
+		// INIT must be the only chunk with zero verification tag
+		if vtag == 0 && len(chunks) == 1 {
+			a.receiveInit(p, src, chunks[0])
+		}
+		return
+	}
+
+	switch c := chunks[0]; {
+	case c.typ == ctAbort && c.flags&flagT == flagT && vtag == a.peerTag:
+	case c.typ == ctShutdownComplete && c.flags&flagT == flagT && vtag == a.peerTag:
+	case c.typ == ctCookieEcho:
+	case vtag != a.localTag:
+		return
+	}
+
+	_, pt := a.findPath(p.from)
+	if pt != nil {
+		// learn encapsulation port of the peer address
+		pt.addr.Port = p.from.Port
+	}
+
+	data := false
+	for _, c := range chunks {
+		switch c.typ {
+		case ctData:
+			if a.state >= stateEstablished && a.state != stateShutdownAckSent {
+				if d, e := parseData(c.flags, c.value); e == nil {
+					a.receiveData(d)
+				}
+				data = true
+			}
+		case ctInitAck:
+			a.receiveInitAck(c)
+		case ctSack:
+			if s, e := parseSack(c.value); e == nil && a.state >= stateEstablished {
+				a.receiveSack(s)
+			}
+		case ctHeartbeat:
+			if pt != nil {
+				a.send(pt, a.peerTag, chunk{typ: ctHeartbeatAck, value: c.value}.bytes())
+			}
+		case ctHeartbeatAck:
+			a.receiveHeartbeatAck(c.value)
+		case ctAbort:
+			a.closed()
+			return
+		case ctShutdown:
+			a.receiveShutdown(c)
+		case ctShutdownAck:
+			if a.state == stateShutdownSent || a.state == stateShutdownAckSent {
+				a.send(a.path(), a.peerTag, chunk{typ: ctShutdownComplete}.bytes())
+				a.closed()
+				return
+			}
+		case ctShutdownComplete:
+			if a.state == stateShutdownAckSent {
+				a.closed()
+				return
+			}
+		case ctCookieEcho:
+			if !a.receiveCookieEcho(p, src, c) {
+				return
+			}
+		case ctCookieAck:
+			if a.state == stateCookieEchoed {
+				a.establish(a.peerTag, a.cumTSN+1, a.peerRwnd, a.outStreams, a.inStreams)
+			}
+		default:
+			// unrecognized chunk
+			if c.typ&0x80 == 0 {
+				return
+			}
+		}
+	}
+	if data {
+		a.sendSack(pt)
+	}
+}
+
+func (a *assoc) receiveInit(p packet, src uint16, c chunk) {
+	init, e := parseInit(c.value)
+	if e != nil {
+		return
+	}
+	pt := a.addPath(&net.UDPAddr{IP: p.from.IP, Port: p.from.Port})
+	pt.conn = p.conn
+	if a.state == stateListen {
+		port := a.t.param(a.t.PeerPort, Port)
+		for _, ip := range init.addrs {
+			a.addPath(&net.UDPAddr{IP: ip, Port: port})
+		}
+	}
+
+	// INIT ACK is sent to source port of INIT
+	ack := a.initChunk(true, a.cookie(init))
+	a.sendTo(pt, src, init.tag, ack)
+}
+
+func (a *assoc) receiveInitAck(c chunk) {
+	if a.state != stateCookieWait {
+		return
+	}
+	ack, e := parseInit(c.value)
+	if e != nil || ack.cookie == nil {
+		return
+	}
+	port := a.t.param(a.t.PeerPort, Port)
+	for _, ip := range ack.addrs {
+		a.addPath(&net.UDPAddr{IP: ip, Port: port})
+	}
+
+	a.peerTag = ack.tag
+	a.cumTSN = ack.tsn - 1
+	a.peerRwnd = ack.rwnd
+	a.mutex.Lock()
+	a.outStreams = min16(uint16(a.t.param(int(a.t.OutStreams), 16)), ack.mis)
+	a.inStreams = min16(uint16(a.t.param(int(a.t.MaxInStreams), 16)), ack.os)
+	a.state = stateCookieEchoed
+	a.mutex.Unlock()
+	a.sendCtrl(chunk{typ: ctCookieEcho, value: ack.cookie}.bytes(), a.peerTag)
+}
+
+func (a *assoc) receiveCookieEcho(p packet, src uint16, c chunk) bool {
+	if len(c.value) != 64 {
+		return false
+	}
+	h := hmac.New(sha256.New, a.secret)
+	h.Write(c.value[:32])
+	if !hmac.Equal(h.Sum(nil), c.value[32:]) {
+		return false
+	}
+	v := c.value
+	if binary.BigEndian.Uint32(v[0:]) != a.localTag ||
+		time.Since(time.Unix(0, int64(binary.BigEndian.Uint64(v[24:])))) > cookieAge {
+		return false
+	}
+	peerTag := binary.BigEndian.Uint32(v[4:])
+
+	if a.state == stateEstablished && peerTag == a.peerTag && src == a.peerPort {
+		// duplicated COOKIE ECHO
+		a.send(a.path(), a.peerTag, chunk{typ: ctCookieAck}.bytes())
+		return true
+	}
+	switch a.state {
+	case stateListen, stateCookieWait, stateCookieEchoed:
+	case stateEstablished:
+		// peer restart
+		a.queue, a.sent, a.t3, a.errCount = nil, nil, time.Time{}, 0
+		a.pending, a.pendLen = make(map[uint32]*dataChunk), 0
+		a.reasm, a.reasmLen, a.discard = a.reasm[:0], 0, false
+	default:
+		return false
+	}
+	a.peerPort = src
+
+	pt := a.addPath(&net.UDPAddr{IP: p.from.IP, Port: p.from.Port})
+	pt.conn = p.conn
+	a.establish(
+		peerTag,
+		binary.BigEndian.Uint32(v[12:]),
+		binary.BigEndian.Uint32(v[16:]),
+		binary.BigEndian.Uint16(v[20:]),
+		binary.BigEndian.Uint16(v[22:]))
+	a.send(pt, a.peerTag, chunk{typ: ctCookieAck}.bytes())
+	return true
+}
+
+func (a *assoc) receiveData(d *dataChunk) {
+	if _, ok := a.pending[d.tsn]; ok || tsnLTE(d.tsn, a.cumTSN) {
+		// duplicated
+		return
+	}
+	if d.tsn-a.cumTSN > 0xffff {
+		// beyond gap ack blocks
+		return
+	}
+	if w := int(a.window()); w < len(d.data) &&
+		(d.tsn != a.cumTSN+1 || a.pendLen == 0) {
+		// out of receive window, except the next TSN
+		// which releases out of order data
+		return
+	}
+	a.pending[d.tsn] = d
+	a.pendLen += len(d.data)
+	for {
+		n, ok := a.pending[a.cumTSN+1]
+		if !ok {
+			break
+		}
+		delete(a.pending, n.tsn)
+		a.pendLen -= len(n.data)
+		a.cumTSN = n.tsn
+		a.deliver(n)
+	}
+}
+
+// window returns free space of receive buffer.
+func (a *assoc) window() uint32 {
+	a.inMutex.Lock()
+	n := a.pendLen + a.reasmLen + a.inLen
+	a.inMutex.Unlock()
+	if n >= rwnd {
+		return 0
+	}
+	return uint32(rwnd - n)
+}
+
+// deliver reassembles fragmented data in TSN order.
+// Message larger than sctp.MaxMessageSize is discarded.
+func (a *assoc) deliver(d *dataChunk) {
+	switch {
+	case d.flags&flagB == flagB:
+		a.reasm, a.reasmLen, a.discard = a.reasm[:0], 0, false
+	case a.discard:
+		a.discard = d.flags&flagE != flagE
+		return
+	case len(a.reasm) == 0:
+		// missing first fragment
+		return
+	}
+	if a.reasmLen+len(d.data) > sctp.MaxMessageSize {
+		a.reasm, a.reasmLen = a.reasm[:0], 0
+		a.discard = d.flags&flagE != flagE
+		return
+	}
+	a.reasm = append(a.reasm, d)
+	a.reasmLen += len(d.data)
+	if d.flags&flagE != flagE {
+		return
+	}
+
+	var b []byte
+	if len(a.reasm) == 1 {
+		b = d.data
+	} else {
+		for _, f := range a.reasm {
+			b = append(b, f.data...)
+		}
+	}
+	a.reasm, a.reasmLen = a.reasm[:0], 0
+
+	a.inMutex.Lock()
+	a.inbox = append(a.inbox, &dataChunk{stream: d.stream, ppid: d.ppid, data: b})
+	a.inLen += len(b)
+	a.inMutex.Unlock()
+	select {
+	case a.inReady <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch calls handleData out of the association goroutine,
+// so that handleData can call Write.
+func (a *assoc) dispatch() {
+	for {
+		select {
+		case <-a.inReady:
+		case <-a.done:
+			return
+		}
+		a.inMutex.Lock()
+		q := a.inbox
+		a.inbox = nil
+		a.inMutex.Unlock()
+		for _, d := range q {
+			a.handleData(d.data, d.stream, d.ppid)
+			a.inMutex.Lock()
+			a.inLen -= len(d.data)
+			a.inMutex.Unlock()
+		}
+	}
+}
+
+func (a *assoc) sendSack(p *path) {
+	s := sackChunk{cumTSN: a.cumTSN, rwnd: a.window()}
+	tsns := make([]uint32, 0, len(a.pending))
+	for t := range a.pending {
+		tsns = append(tsns, t-a.cumTSN)
+	}
+	sort.Slice(tsns, func(i, j int) bool { return tsns[i] < tsns[j] })
+	for _, o := range tsns {
+		if o > 0xffff {
+			break
+		}
+		if n := len(s.gaps); n != 0 && uint32(s.gaps[n-1][1])+1 == o {
+			s.gaps[n-1][1] = uint16(o)
+		} else {
+			s.gaps = append(s.gaps, [2]uint16{uint16(o), uint16(o)})
+		}
+	}
+	if p == nil {
+		p = a.path()
+	}
+	a.send(p, a.peerTag, s.bytes())
+}
+
+func (a *assoc) enqueue(b []byte, s uint16) {
+	ppid := a.t.PPID
+	if ppid == 0 {
+		ppid = 4
+	}
+	const frag = mtu - 12 - 16
+	ssn := a.ssn[s]
+	a.ssn[s]++
+	for i := 0; i == 0 || i < len(b); i += frag {
+		d := &dataChunk{
+			stream: s,
+			ssn:    ssn,
+			ppid:   ppid}
+		if i == 0 {
+			d.flags |= flagB
+		}
+		if i+frag >= len(b) {
+			d.flags |= flagE
+			d.data = b[i:]
+		} else {
+			d.data = b[i : i+frag]
+		}
+		a.queue = append(a.queue, &outData{dataChunk: d, length: len(d.data)})
+	}
+}
+
+// flight returns size of outstanding data.
+func (a *assoc) flight() (n int) {
+	for _, d := range a.sent {
+		if !d.acked {
+			n += d.length
+		}
+	}
+	return
+}
+
+// flush sends queued data within congestion window
+// and receiver window of the peer.
+func (a *assoc) flush() {
+	flight := a.flight()
+
+	p := a.path()
+	wnd := p.cwnd
+	if int(a.peerRwnd) < wnd {
+		wnd = int(a.peerRwnd)
+	}
+	var chunks [][]byte
+	size := 12
+	now := time.Now()
+	for len(a.queue) != 0 {
+		d := a.queue[0]
+		if len(a.sent) != 0 && flight+d.length > wnd {
+			break
+		}
+		a.queue = a.queue[1:]
+		d.tsn = a.nextTSN
+		a.nextTSN++
+		d.sent = now
+		a.sent = append(a.sent, d)
+		flight += d.length
+
+		b := d.bytes()
+		if size+len(b) > mtu && len(chunks) != 0 {
+			a.send(p, a.peerTag, chunks...)
+			chunks = nil
+			size = 12
+		}
+		chunks = append(chunks, b)
+		size += len(b)
+	}
+	if len(chunks) != 0 {
+		a.send(p, a.peerTag, chunks...)
+	}
+	if len(a.sent) != 0 && a.t3.IsZero() {
+		a.t3 = now.Add(p.rto)
+	}
+}
+
+func (a *assoc) receiveSack(s sackChunk) {
+	now := time.Now()
+	p := a.path()
+	before := a.flight()
+	acked := 0
+	i := 0
+	for ; i < len(a.sent) && tsnLTE(a.sent[i].tsn, s.cumTSN); i++ {
+		d := a.sent[i]
+		if !d.retx && i == 0 {
+			a.updateRto(p, now.Sub(d.sent))
+		}
+		if !d.acked {
+			acked += d.length
+		}
+	}
+	a.updateCwnd(p, acked, before)
+	if i != 0 {
+		a.sent = a.sent[i:]
+		a.errCount = 0
+		p.errors = 0
+		if len(a.sent) != 0 {
+			a.t3 = now.Add(p.rto)
+		} else {
+			a.t3 = time.Time{}
+		}
+	}
+	for _, d := range a.sent {
+		for _, g := range s.gaps {
+			if !tsnLT(d.tsn, s.cumTSN+uint32(g[0])) && tsnLTE(d.tsn, s.cumTSN+uint32(g[1])) {
+				d.acked = true
+			}
+		}
+	}
+
+	flight := a.flight()
+	if int(s.rwnd) > flight {
+		a.peerRwnd = s.rwnd - uint32(flight)
+	} else {
+		a.peerRwnd = 0
+	}
+	a.flush()
+
+	if len(a.sent) == 0 && len(a.queue) == 0 {
+		for _, c := range a.dry {
+			close(c)
+		}
+		a.dry = nil
+
+		switch a.state {
+		case stateShutdownPending:
+			a.shutdown()
+		case stateShutdownReceived:
+			a.state = stateShutdownAckSent
+			a.sendCtrl(chunk{typ: ctShutdownAck}.bytes(), a.peerTag)
+		}
+	}
+}
+
+// updateCwnd updates congestion window of p by acked bytes
+// with outstanding data size before the SACK.
+func (a *assoc) updateCwnd(p *path, acked, flight int) {
+	switch {
+	case acked == 0:
+	case p.cwnd <= p.ssthresh:
+		// slow start
+		if flight >= p.cwnd {
+			if acked > mtu {
+				acked = mtu
+			}
+			p.cwnd += acked
+		}
+	default:
+		// congestion avoidance
+		p.pba += acked
+		if p.pba >= p.cwnd && flight >= p.cwnd {
+			p.pba -= p.cwnd
+			p.cwnd += mtu
+		}
+	}
+	if flight == acked {
+		p.pba = 0
+	}
+}
+
+func (a *assoc) updateRto(p *path, r time.Duration) {
+	if p.srtt == 0 {
+		p.srtt = r
+		p.rttvar = r / 2
+	} else {
+		d := p.srtt - r
+		if d < 0 {
+			d = -d
+		}
+		p.rttvar = p.rttvar*3/4 + d/4
+		p.srtt = p.srtt*7/8 + r/8
+	}
+	p.rto = p.srtt + 4*p.rttvar
+	if min := a.t.duration(a.t.RtoMin, time.Second); p.rto < min {
+		p.rto = min
+	}
+	if max := a.t.duration(a.t.RtoMax, time.Minute); p.rto > max {
+		p.rto = max
+	}
+}
+
+func (a *assoc) receiveHeartbeatAck(v []byte) {
+	var i int
+	var t time.Time
+	parseParameters(v, func(typ uint16, p []byte) {
+		if typ == 1 && len(p) == 12 {
+			i = int(binary.BigEndian.Uint32(p))
+			t = time.Unix(0, int64(binary.BigEndian.Uint64(p[4:])))
+		}
+	})
+	if t.IsZero() || i >= len(a.paths) {
+		return
+	}
+	p := a.paths[i]
+	p.hbPending = false
+	p.errors = 0
+	p.active = true
+	a.errCount = 0
+	a.updateRto(p, time.Since(t))
+}
+
+func (a *assoc) receiveShutdown(c chunk) {
+	if len(c.value) < 4 {
+		return
+	}
+	switch a.state {
+	case stateEstablished, stateShutdownPending, stateShutdownReceived:
+		a.receiveSack(sackChunk{
+			cumTSN: binary.BigEndian.Uint32(c.value),
+			rwnd:   a.peerRwnd})
+		a.state = stateShutdownReceived
+		if len(a.sent) == 0 && len(a.queue) == 0 {
+			a.state = stateShutdownAckSent
+			a.sendCtrl(chunk{typ: ctShutdownAck}.bytes(), a.peerTag)
+		}
+	case stateShutdownSent:
+		a.state = stateShutdownAckSent
+		a.sendCtrl(chunk{typ: ctShutdownAck}.bytes(), a.peerTag)
+	}
+}
+
+func (a *assoc) shutdown() {
+	if len(a.sent) != 0 || len(a.queue) != 0 {
+		return
+	}
+	a.setState(stateShutdownSent)
+	a.sendCtrl(cumTSNChunk(ctShutdown, a.cumTSN), a.peerTag)
+}
+
+func (a *assoc) timer(now time.Time) {
+	// T1-init, T1-cookie, T2-shutdown
+	if a.ctrl != nil && now.After(a.ctrlTime) {
+		a.ctrlCount++
+		max := a.t.param(a.t.AssocMaxRetrans, 10)
+		if a.state == stateCookieWait || a.state == stateCookieEchoed {
+			max = a.t.param(a.t.MaxInitAttempts, 8)
+		}
+		if a.ctrlCount > max {
+			a.abort("")
+			return
+		}
+		p := a.path()
+		a.backoff(p)
+		if a.ctrlCount%2 == 0 && len(a.paths) > 1 {
+			// try alternate path
+			a.primary = (a.primary + 1) % len(a.paths)
+			p = a.path()
+		}
+		a.ctrlTime = now.Add(p.rto)
+		vtag := a.peerTag
+		if a.state == stateCookieWait {
+			vtag = 0
+		}
+		a.send(p, vtag, a.ctrl)
+	}
+
+	// T3-rtx
+	if !a.t3.IsZero() && now.After(a.t3) {
+		p := a.path()
+		a.backoff(p)
+		p.ssthresh = p.cwnd / 2
+		if p.ssthresh < 4*mtu {
+			p.ssthresh = 4 * mtu
+		}
+		p.cwnd, p.pba = mtu, 0
+		if a.errCount++; a.errCount > a.t.param(a.t.AssocMaxRetrans, 10) {
+			a.abort("")
+			return
+		}
+		if p.errors++; p.errors > a.t.param(a.t.PathMaxRetrans, 5) {
+			p.active = false
+		}
+		p = a.path()
+
+		var chunks [][]byte
+		size := 12
+		for _, d := range a.sent {
+			if d.acked {
+				continue
+			}
+			d.retx = true
+			b := d.bytes()
+			if size+len(b) > mtu && len(chunks) != 0 {
+				break
+			}
+			chunks = append(chunks, b)
+			size += len(b)
+		}
+		if len(chunks) != 0 {
+			a.send(p, a.peerTag, chunks...)
+		}
+		a.t3 = now.Add(p.rto)
+	}
+
+	// Heartbeat
+	if a.state == stateEstablished {
+		hb := a.t.duration(a.t.HeartbeatInterval, 30*time.Second)
+		for i, p := range a.paths {
+			if now.Before(p.hbTime.Add(hb + p.rto)) {
+				continue
+			}
+			if p.hbPending {
+				a.backoff(p)
+				if p.errors++; p.errors > a.t.param(a.t.PathMaxRetrans, 5) {
+					p.active = false
+				}
+			}
+			info := make([]byte, 12)
+			binary.BigEndian.PutUint32(info, uint32(i))
+			binary.BigEndian.PutUint64(info[4:], uint64(now.UnixNano()))
+			a.send(p, a.peerTag, chunk{typ: ctHeartbeat, value: parameter(1, info)}.bytes())
+			p.hbTime = now
+			p.hbPending = true
+		}
+	}
+}
+
+func (a *assoc) backoff(p *path) {
+	p.rto *= 2
+	if max := a.t.duration(a.t.RtoMax, time.Minute); p.rto > max {
+		p.rto = max
+	}
+}
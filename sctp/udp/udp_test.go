@@ -0,0 +1,287 @@
+package udp
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fkgi/xua/sctp"
+)
+
+// relay forwards UDP packets between client and server.
+// Packets that drop returns true are discarded.
+type relay struct {
+	conn   *net.UDPConn
+	server *net.UDPAddr
+
+	mutex  sync.Mutex
+	client *net.UDPAddr
+	drop   func([]byte) bool
+}
+
+func newRelay(t *testing.T, server int) *relay {
+	c, e := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if e != nil {
+		t.Fatal(e)
+	}
+	r := &relay{conn: c, server: &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: server}}
+	t.Cleanup(func() { c.Close() })
+	go func() {
+		buf := make([]byte, 65536)
+		for {
+			n, from, e := c.ReadFromUDP(buf)
+			if e != nil {
+				return
+			}
+			r.mutex.Lock()
+			to := r.server
+			if from.Port == r.server.Port {
+				to = r.client
+			} else {
+				r.client = from
+			}
+			drop := r.drop != nil && r.drop(buf[:n])
+			r.mutex.Unlock()
+			if !drop && to != nil {
+				c.WriteToUDP(buf[:n], to)
+			}
+		}
+	}()
+	return r
+}
+
+func (r *relay) port() int {
+	return r.conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func (r *relay) setDrop(f func([]byte) bool) {
+	r.mutex.Lock()
+	r.drop = f
+	r.mutex.Unlock()
+}
+
+// freePort returns unused UDP port.
+func freePort(t *testing.T) int {
+	c, e := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer c.Close()
+	return c.LocalAddr().(*net.UDPAddr).Port
+}
+
+type message struct {
+	data   []byte
+	stream uint16
+	ppid   uint32
+}
+
+// endpoint is served Transport with received data and events.
+type endpoint struct {
+	*Transport
+	rx   chan message
+	up   chan struct{}
+	down chan struct{}
+	done chan error
+}
+
+func serve(t *testing.T, tr *Transport) *endpoint {
+	ep := &endpoint{
+		Transport: tr,
+		rx:        make(chan message, 16),
+		up:        make(chan struct{}),
+		down:      make(chan struct{}),
+		done:      make(chan error, 1)}
+	go func() {
+		ep.done <- tr.Serve(func(b []byte, s uint16, p uint32) {
+			ep.rx <- message{data: b, stream: s, ppid: p}
+		}, func() { close(ep.up) }, func() { close(ep.down) })
+	}()
+	t.Cleanup(func() { tr.Abort("") })
+	return ep
+}
+
+func (ep *endpoint) waitUp(t *testing.T) {
+	t.Helper()
+	select {
+	case <-ep.up:
+	case <-time.After(time.Second * 3):
+		t.Fatal("association is not established")
+	}
+}
+
+func (ep *endpoint) recv(t *testing.T) message {
+	t.Helper()
+	select {
+	case m := <-ep.rx:
+		return m
+	case <-time.After(time.Second * 3):
+		t.Fatal("no data")
+	}
+	return message{}
+}
+
+// newPair establishes association between client and server
+// with different SCTP ports through relay.
+func newPair(t *testing.T) (c, s *endpoint, r *relay) {
+	sport := freePort(t)
+	r = newRelay(t, sport)
+	s = serve(t, &Transport{
+		LocalAddr:  &sctp.SCTPAddr{Port: 2905},
+		LocalPort:  sport,
+		RtoInitial: time.Millisecond * 200,
+		RtoMin:     time.Millisecond * 100})
+	c = serve(t, &Transport{
+		LocalAddr:  &sctp.SCTPAddr{Port: 3905},
+		PeerAddr:   &sctp.SCTPAddr{IP: []net.IP{net.IPv4(127, 0, 0, 1)}, Port: 2905},
+		LocalPort:  freePort(t),
+		PeerPort:   r.port(),
+		PPID:       3,
+		OutStreams: 4,
+		RtoInitial: time.Millisecond * 200,
+		RtoMin:     time.Millisecond * 100})
+	c.waitUp(t)
+	s.waitUp(t)
+	return
+}
+
+// isData returns true if the first chunk of packet b is DATA.
+func isData(b []byte) bool {
+	return len(b) > 12 && b[12] == ctData
+}
+
+func TestHandshake(t *testing.T) {
+	c, s, _ := newPair(t)
+
+	if out, in := c.Streams(); out != 4 || in != 16 {
+		t.Errorf("client streams out=%d in=%d", out, in)
+	}
+	if out, in := s.Streams(); out != 16 || in != 4 {
+		t.Errorf("server streams out=%d in=%d", out, in)
+	}
+}
+
+func TestDataSack(t *testing.T) {
+	c, s, _ := newPair(t)
+
+	for i := uint16(0); i < 3; i++ {
+		if e := c.Write([]byte{byte(i)}, i); e != nil {
+			t.Fatal(e)
+		}
+	}
+	for i := uint16(0); i < 3; i++ {
+		m := s.recv(t)
+		if !bytes.Equal(m.data, []byte{byte(i)}) || m.stream != i || m.ppid != 3 {
+			t.Errorf("invalid data %+v", m)
+		}
+	}
+	if e := s.Write([]byte("answer"), 1); e != nil {
+		t.Fatal(e)
+	}
+	if m := c.recv(t); string(m.data) != "answer" || m.stream != 1 || m.ppid != 4 {
+		t.Errorf("invalid data %+v", m)
+	}
+
+	// Flush returns by SACK
+	for _, ep := range []*endpoint{c, s} {
+		if e := ep.Flush(context.Background()); e != nil {
+			t.Error(e)
+		}
+	}
+	if e := c.Write([]byte{0}, 4); e == nil {
+		t.Error("data is sent on invalid stream")
+	}
+}
+
+func TestRetransmission(t *testing.T) {
+	c, s, r := newPair(t)
+
+	lost := make(chan struct{})
+	r.setDrop(func(b []byte) bool {
+		select {
+		case <-lost:
+			return false
+		default:
+		}
+		if isData(b) {
+			close(lost)
+			return true
+		}
+		return false
+	})
+	if e := c.Write([]byte("lost"), 0); e != nil {
+		t.Fatal(e)
+	}
+	if m := s.recv(t); string(m.data) != "lost" {
+		t.Errorf("invalid data %q", m.data)
+	}
+	if e := c.Flush(context.Background()); e != nil {
+		t.Error(e)
+	}
+	select {
+	case <-lost:
+	default:
+		t.Error("DATA is not dropped")
+	}
+}
+
+func TestFragmentation(t *testing.T) {
+	c, s, _ := newPair(t)
+
+	b := make([]byte, 10000)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	if e := c.Write(b, 2); e != nil {
+		t.Fatal(e)
+	}
+	if m := s.recv(t); !bytes.Equal(m.data, b) || m.stream != 2 {
+		t.Errorf("invalid data length=%d stream=%d", len(m.data), m.stream)
+	}
+
+	// message larger than sctp.MaxMessageSize is discarded
+	if e := c.Write(make([]byte, sctp.MaxMessageSize+1), 0); e != nil {
+		t.Fatal(e)
+	}
+	if e := c.Write([]byte("next"), 0); e != nil {
+		t.Fatal(e)
+	}
+	if m := s.recv(t); string(m.data) != "next" {
+		t.Errorf("invalid data length=%d", len(m.data))
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	c, s, _ := newPair(t)
+
+	if e := c.Write([]byte("last"), 0); e != nil {
+		t.Fatal(e)
+	}
+	if e := c.Close(); e != nil {
+		t.Fatal(e)
+	}
+	if m := s.recv(t); string(m.data) != "last" {
+		t.Errorf("invalid data %q", m.data)
+	}
+	for _, ep := range []*endpoint{c, s} {
+		select {
+		case <-ep.down:
+		case <-time.After(time.Second * 3):
+			t.Fatal("handleDown is not called")
+		}
+		select {
+		case e := <-ep.done:
+			if e != nil {
+				t.Errorf("serve: %v", e)
+			}
+		case <-time.After(time.Second * 3):
+			t.Fatal("Serve does not return")
+		}
+	}
+	if e := c.Write([]byte{0}, 0); e == nil {
+		t.Error("data is sent after shutdown")
+	}
+}
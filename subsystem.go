@@ -0,0 +1,137 @@
+package xua
+
+import (
+	"sync"
+
+	"github.com/fkgi/xua/codec"
+)
+
+var (
+	// SubsystemQueue is length of received data queue of each local subsystem.
+	// Data is returned with subsystem congestion when the queue is full.
+	SubsystemQueue = 1024
+
+	subsystemLock  sync.RWMutex
+	localSubsystem = map[uint8]localState{}
+)
+
+type localState struct {
+	handler func(cgpa, cdpa SCCPAddress, b []byte)
+	queue   chan *CLDT
+	allowed bool
+}
+
+// RegisterSubsystem registers local subsystem ssn in allowed state.
+// Received data to ssn is passed to h with calling and called party address.
+// h is called in order on a goroutine of the subsystem.
+func RegisterSubsystem(ssn uint8, h func(cgpa, cdpa SCCPAddress, b []byte)) {
+	q := make(chan *CLDT, SubsystemQueue)
+	go func() {
+		for m := range q {
+			h(m.SourceAddress, m.DestinationAddress, m.Data)
+		}
+	}()
+
+	subsystemLock.Lock()
+	s := localSubsystem[ssn]
+	if s.queue != nil {
+		close(s.queue)
+	}
+	s.handler = h
+	s.queue = q
+	localSubsystem[ssn] = s
+	subsystemLock.Unlock()
+	SetSubsystem(ssn, true)
+}
+
+// DeregisterSubsystem sets local subsystem ssn prohibited and removes it.
+func DeregisterSubsystem(ssn uint8) {
+	subsystemLock.RLock()
+	_, ok := localSubsystem[ssn]
+	subsystemLock.RUnlock()
+	if !ok {
+		return
+	}
+	SetSubsystem(ssn, false)
+	subsystemLock.Lock()
+	if s := localSubsystem[ssn]; s.queue != nil {
+		close(s.queue)
+	}
+	delete(localSubsystem, ssn)
+	subsystemLock.Unlock()
+}
+
+// SetSubsystem sets state of local subsystem ssn,
+// and sends SSA or SSP to ConcernedPointCode if the state is changed.
+func SetSubsystem(ssn uint8, allowed bool) {
+	subsystemLock.Lock()
+	s, ok := localSubsystem[ssn]
+	old := s.allowed
+	s.allowed = allowed
+	localSubsystem[ssn] = s
+	subsystemLock.Unlock()
+	if !ok || old != allowed {
+		broadcastSCMG(ssn, allowed)
+	}
+}
+
+// hasHandler returns true if any local subsystem has handler.
+func hasHandler() bool {
+	subsystemLock.RLock()
+	defer subsystemLock.RUnlock()
+	for _, s := range localSubsystem {
+		if s.handler != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// localAllowed returns true if local subsystem ssn is allowed.
+func localAllowed(ssn uint8) bool {
+	subsystemLock.RLock()
+	defer subsystemLock.RUnlock()
+	return localSubsystem[ssn].allowed
+}
+
+// deliver passes received data to the handler of called party SSN.
+// Data is passed to the handler of Serve if no handler is registered.
+// Undeliverable data is returned by CLDR if return on error is requested.
+func deliver(m *CLDT) {
+	ssn := m.DestinationAddress.SubsystemNumber
+	switch {
+	case ssn == scmgSSN:
+		handleSCMG(m)
+	case !hasHandler():
+		if handler != nil {
+			handler(m.Data)
+		} else {
+			m.returnData(codec.CauseUnequippedUser)
+		}
+	case ssn == 0:
+		// no SSN in called party address
+		m.returnData(codec.CauseNoTranslationForAddress)
+	default:
+		m.returnData(enqueue(ssn, m))
+	}
+}
+
+// enqueue queues m to local subsystem ssn,
+// and returns cause if m can not be queued.
+func enqueue(ssn uint8, m *CLDT) uint32 {
+	subsystemLock.RLock()
+	defer subsystemLock.RUnlock()
+	s := localSubsystem[ssn]
+	switch {
+	case s.handler == nil:
+		return codec.CauseUnequippedUser
+	case !s.allowed:
+		return codec.CauseSubsystemFailure
+	}
+	select {
+	case s.queue <- m:
+		return 0
+	default:
+		return codec.CauseSubsystemCongestion
+	}
+}
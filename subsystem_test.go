@@ -0,0 +1,119 @@
+package xua
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fkgi/xua/codec"
+)
+
+type ssnData struct {
+	cgpa, cdpa SCCPAddress
+	data       string
+}
+
+// ssnHandler returns handler of local subsystem which passes data to ch.
+func ssnHandler(ch chan ssnData) func(cgpa, cdpa SCCPAddress, b []byte) {
+	return func(cgpa, cdpa SCCPAddress, b []byte) {
+		ch <- ssnData{cgpa: cgpa, cdpa: cdpa, data: string(b)}
+	}
+}
+
+// dataCLDT returns CLDT from remote SSN 6 to local ssn.
+func dataCLDT(ssn uint8, data string) *codec.CLDT {
+	return &codec.CLDT{
+		SourceAddress: SCCPAddress{
+			PointCode: PointCode{Value: 200}, SubsystemNumber: 6},
+		DestinationAddress: SCCPAddress{
+			PointCode: PointCode{Value: 100}, SubsystemNumber: ssn},
+		Data: []byte(data)}
+}
+
+func TestPipeSubsystemRouting(t *testing.T) {
+	resetSubsystem(t)
+	ch8 := make(chan ssnData, 4)
+	ch9 := make(chan ssnData, 4)
+	RegisterSubsystem(8, ssnHandler(ch8))
+	RegisterSubsystem(9, ssnHandler(ch9))
+
+	sg := newFakeSG(t)
+	fallback := make(chan []byte, 4)
+	done := serveASP(t, func(b []byte) { fallback <- b })
+	sg.recv(t)
+	sg.recv(t)
+
+	expect := func(ch chan ssnData, data string) {
+		t.Helper()
+		select {
+		case d := <-ch:
+			if d.data != data ||
+				d.cgpa.SubsystemNumber != 6 || d.cgpa.PointCode.Value != 200 ||
+				d.cdpa.PointCode.Value != 100 {
+				t.Errorf("invalid data %+v", d)
+			}
+		case <-time.After(time.Second * 3):
+			t.Fatalf("data %q is not received", data)
+		}
+	}
+
+	// data is passed to the handler of called party SSN
+	sg.write(dataCLDT(8, "to 8"))
+	sg.write(dataCLDT(9, "to 9"))
+	sg.write(dataCLDT(8, "to 8 again"))
+	expect(ch8, "to 8")
+	expect(ch8, "to 8 again")
+	expect(ch9, "to 9")
+
+	// data to unknown SSN is not passed to any handler
+	sg.write(dataCLDT(10, "to 10"))
+	select {
+	case b := <-fallback:
+		t.Errorf("data %q is passed to handleData", b)
+	case d := <-ch8:
+		t.Errorf("data %+v is passed to SSN 8", d)
+	case d := <-ch9:
+		t.Errorf("data %+v is passed to SSN 9", d)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// handleData is used when all subsystems are deregistered
+	DeregisterSubsystem(8)
+	sg.write(dataCLDT(9, "to 9"))
+	expect(ch9, "to 9")
+	DeregisterSubsystem(9)
+	sg.write(dataCLDT(8, "to 8"))
+	select {
+	case b := <-fallback:
+		if string(b) != "to 8" {
+			t.Errorf("invalid data %q", b)
+		}
+	case <-time.After(time.Second * 3):
+		t.Fatal("data is not passed to handleData")
+	}
+
+	closeASP(t, done)
+	sg.recv(t)
+}
+
+func TestDeregisterSubsystem(t *testing.T) {
+	resetSubsystem(t)
+	RegisterSubsystem(8, func(cgpa, cdpa SCCPAddress, b []byte) {})
+	if !hasHandler() || !localAllowed(8) {
+		t.Fatal("subsystem is not registered")
+	}
+	DeregisterSubsystem(8)
+	if hasHandler() || localAllowed(8) {
+		t.Error("subsystem is not deregistered")
+	}
+	if enqueue(8, &CLDT{}) != codec.CauseUnequippedUser {
+		t.Error("data is queued to deregistered subsystem")
+	}
+
+	// deregistering unknown subsystem is no-op
+	DeregisterSubsystem(9)
+	subsystemLock.RLock()
+	defer subsystemLock.RUnlock()
+	if len(localSubsystem) != 0 {
+		t.Errorf("unknown subsystem is changed %+v", localSubsystem)
+	}
+}
@@ -0,0 +1,55 @@
+package xua
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ReconnectInterval is initial wait time before reconnection.
+	ReconnectInterval = time.Second
+	// MaxReconnectInterval is upper limit of exponential backoff.
+	MaxReconnectInterval = time.Minute
+)
+
+// Supervise runs Serve and reconnects with exponential backoff
+// when the association is lost or can not be established.
+// ASPUP and ASPAC are sent again on each association.
+// handleRetry is called before each wait with attempt count, wait time
+// and cause of the disconnection. It returns when ctx is done.
+func Supervise(ctx context.Context, handleData func([]byte), handleUp, handleDown func(),
+	handleRetry func(int, time.Duration, error)) error {
+	wait := ReconnectInterval
+	for attempt := 1; ; attempt++ {
+		var active atomic.Bool
+		e := ServeContext(ctx, handleData, func() {
+			active.Store(true)
+			handleUp()
+		}, handleDown)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if active.Load() {
+			// reset backoff after successful activation
+			wait = ReconnectInterval
+			attempt = 1
+		}
+		if e == nil {
+			e = errors.New("association is closed")
+		}
+		if handleRetry != nil {
+			handleRetry(attempt, wait, e)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if wait *= 2; wait > MaxReconnectInterval {
+			wait = MaxReconnectInterval
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package xua
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fkgi/xua/codec"
+)
+
+// redialer is Transport which connects to new fakeSG on each Serve.
+// ASPUP is rejected on the first reject connections.
+// fakeSG of accepted connection is passed to sgs.
+type redialer struct {
+	t      *testing.T
+	reject int
+	sgs    chan *fakeSG
+
+	mutex sync.Mutex
+	count int
+	cur   Transport
+}
+
+func (r *redialer) Serve(handleData func([]byte, uint16, uint32), handleUp, handleDown func()) error {
+	a, b := Pipe()
+	sg := startFakeSG(r.t, b)
+	r.mutex.Lock()
+	r.count++
+	if r.count <= r.reject {
+		sg.reject = ErrRefusedManagementBlocking
+	} else {
+		r.sgs <- sg
+	}
+	r.cur = a
+	r.mutex.Unlock()
+	return a.Serve(handleData, handleUp, handleDown)
+}
+
+func (r *redialer) current() Transport {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.cur
+}
+
+func (r *redialer) Write(b []byte, s uint16) error  { return r.current().Write(b, s) }
+func (r *redialer) Streams() (out, in uint16)       { return r.current().Streams() }
+func (r *redialer) Flush(ctx context.Context) error { return r.current().Flush(ctx) }
+func (r *redialer) Close() error                    { return r.current().Close() }
+func (r *redialer) Abort(reason string) error       { return r.current().Abort(reason) }
+
+func TestPipeSupervise(t *testing.T) {
+	ri, mri := ReconnectInterval, MaxReconnectInterval
+	ReconnectInterval = time.Millisecond * 10
+	MaxReconnectInterval = time.Millisecond * 40
+	defer func() { ReconnectInterval, MaxReconnectInterval = ri, mri }()
+
+	r := &redialer{t: t, reject: 4, sgs: make(chan *fakeSG, 4)}
+	old := DefaultTransport
+	DefaultTransport = r
+	defer func() { DefaultTransport = old }()
+
+	type retry struct {
+		attempt int
+		wait    time.Duration
+	}
+	retries := make(chan retry, 16)
+	up := make(chan struct{}, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- Supervise(ctx, func([]byte) {},
+			func() { up <- struct{}{} }, func() {},
+			func(n int, d time.Duration, e error) {
+				if e == nil {
+					t.Error("no cause of retry")
+				}
+				retries <- retry{n, d}
+			})
+	}()
+	waitRetry := func(want retry) {
+		t.Helper()
+		select {
+		case r := <-retries:
+			if r != want {
+				t.Errorf("retry %+v, want %+v", r, want)
+			}
+		case <-time.After(time.Second * 3):
+			t.Fatal("no retry")
+		}
+	}
+
+	// backoff grows while ASPUP is rejected, and is capped
+	for i, w := range []time.Duration{10, 20, 40, 40} {
+		waitRetry(retry{i + 1, w * time.Millisecond})
+	}
+
+	for i := 0; i < 2; i++ {
+		var sg *fakeSG
+		select {
+		case sg = <-r.sgs:
+		case <-time.After(time.Second * 3):
+			t.Fatal("not reconnected")
+		}
+		// ASPUP and ASPAC are sent on each association
+		if _, ok := sg.recv(t).(*codec.ASPUP); !ok {
+			t.Fatal("ASPUP is not sent")
+		}
+		if _, ok := sg.recv(t).(*codec.ASPAC); !ok {
+			t.Fatal("ASPAC is not sent")
+		}
+		select {
+		case <-up:
+		case <-time.After(time.Second * 3):
+			t.Fatal("handleUp is not called")
+		}
+		if i == 0 {
+			// backoff is reset after the active association is lost
+			sg.Close()
+			waitRetry(retry{1, 10 * time.Millisecond})
+		}
+	}
+
+	// cancel stops the loop
+	cancel()
+	select {
+	case e := <-done:
+		if !errors.Is(e, context.Canceled) {
+			t.Errorf("supervise: %v", e)
+		}
+	case <-time.After(time.Second * 3):
+		t.Fatal("Supervise does not return")
+	}
+	select {
+	case r := <-retries:
+		t.Errorf("retry %+v after cancel", r)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
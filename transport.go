@@ -0,0 +1,135 @@
+package xua
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/fkgi/xua/sctp"
+)
+
+// Transport is underlying transport of xUA messages.
+type Transport interface {
+	// Serve starts the transport and handles received message with
+	// its stream number and payload protocol ID.
+	// handleUp and handleDown are called when the transport is up and down.
+	Serve(handleData func([]byte, uint16, uint32), handleUp, handleDown func()) error
+
+	// Write sends message on the stream.
+	Write([]byte, uint16) error
+
+	// Streams returns number of outbound and inbound streams.
+	Streams() (out, in uint16)
+
+	// Flush waits until all sent messages are acknowledged by the peer
+	// or ctx is done.
+	Flush(ctx context.Context) error
+
+	// Close closes the transport gracefully.
+	Close() error
+
+	// Abort closes the transport with abort reason.
+	Abort(string) error
+}
+
+// DefaultTransport is Transport used by Serve.
+var DefaultTransport Transport = sctp.Transport{}
+
+// Pipe returns a pair of connected in-memory Transport.
+// Both ends become up when both of them are served.
+func Pipe() (Transport, Transport) {
+	a := &pipe{
+		rx:    make(chan pipeMsg, 1024),
+		ready: make(chan struct{}),
+		done:  make(chan struct{}),
+		once:  new(sync.Once)}
+	b := &pipe{
+		rx:    make(chan pipeMsg, 1024),
+		ready: make(chan struct{}),
+		done:  a.done,
+		once:  a.once}
+	a.peer, b.peer = b, a
+	return a, b
+}
+
+const (
+	pipeStreams uint16 = 16
+	pipePPID    uint32 = 4
+)
+
+type pipeMsg struct {
+	data   []byte
+	stream uint16
+}
+
+type pipe struct {
+	rx    chan pipeMsg
+	ready chan struct{}
+	peer  *pipe
+
+	done chan struct{}
+	once *sync.Once
+}
+
+func (p *pipe) Serve(handleData func([]byte, uint16, uint32), handleUp, handleDown func()) error {
+	select {
+	case <-p.ready:
+		return errors.New("pipe is already served")
+	default:
+		close(p.ready)
+	}
+
+	select {
+	case <-p.peer.ready:
+		go handleUp()
+	case <-p.done:
+		return errors.New("pipe is closed")
+	}
+
+	for {
+		select {
+		case m := <-p.rx:
+			handleData(m.data, m.stream, pipePPID)
+		case <-p.done:
+			go handleDown()
+			return nil
+		}
+	}
+}
+
+func (p *pipe) Write(b []byte, s uint16) error {
+	if s >= pipeStreams {
+		return errors.New("invalid stream")
+	}
+	m := pipeMsg{data: make([]byte, len(b)), stream: s}
+	copy(m.data, b)
+
+	select {
+	case <-p.done:
+		return errors.New("pipe is closed")
+	default:
+	}
+	select {
+	case p.peer.rx <- m:
+		return nil
+	case <-p.done:
+		return errors.New("pipe is closed")
+	}
+}
+
+func (p *pipe) Streams() (out, in uint16) {
+	return pipeStreams, pipeStreams
+}
+
+func (p *pipe) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (p *pipe) Close() error {
+	p.once.Do(func() { close(p.done) })
+	return nil
+}
+
+func (p *pipe) Abort(reason string) error {
+	return p.Close()
+}